@@ -0,0 +1,117 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"unicode/utf8"
+)
+
+// scanASCII consumes the run of buffered ASCII bytes that may appear unescaped in the body of an IRI,
+// appending them to r.buf. It stops before any byte that needs to be examined by the caller, including the
+// first byte of a multibyte sequence, so that most terms are read a buffer at a time rather than a rune at a
+// time. The limits on the length of a statement and of a term are respected.
+func (r *Reader) scanASCII(maxLen int) {
+	if r.newline {
+		return
+	}
+	buf, _ := r.r.Peek(r.r.Buffered())
+	if r.maxLine > 0 {
+		buf = limitBytes(buf, r.maxLine-r.lineBytes)
+	}
+	if maxLen > 0 {
+		buf = limitBytes(buf, maxLen-r.buf.Len())
+	}
+
+	n := 0
+	for n < len(buf) && isIRIByte(buf[n]) {
+		n++
+	}
+	if n > 0 {
+		r.consume(buf[:n], n, rune(buf[n-1]))
+	}
+}
+
+// scanLiteral consumes the run of buffered text that may appear unescaped in the body of a literal, up to
+// the next quote, backslash or line break, appending it to r.buf. Unlike scanASCII it includes valid
+// multibyte sequences, which need no further checks in a literal, so the text between escapes is copied
+// in one step whatever its script. It stops before any invalid UTF-8, which is left for readRune to report.
+func (r *Reader) scanLiteral(maxLen int) {
+	if r.newline {
+		return
+	}
+	buf, _ := r.r.Peek(r.r.Buffered())
+	if r.maxLine > 0 {
+		buf = limitBytes(buf, r.maxLine-r.lineBytes)
+	}
+	if maxLen > 0 {
+		buf = limitBytes(buf, maxLen-r.buf.Len())
+	}
+
+	n, runes := 0, 0
+	var last rune
+	for n < len(buf) {
+		if b := buf[n]; b < utf8.RuneSelf {
+			if !isLiteralByte(b) {
+				break
+			}
+			last = rune(b)
+			n++
+		} else {
+			r1, size := utf8.DecodeRune(buf[n:])
+			if r1 == utf8.RuneError && size == 1 {
+				break
+			}
+			last = r1
+			n += size
+		}
+		runes++
+	}
+	if n > 0 {
+		r.consume(buf[:n], runes, last)
+	}
+}
+
+// consume moves past b, which holds the given number of runes ending with last, as though it had been
+// read by readRune and appended to r.buf.
+func (r *Reader) consume(b []byte, runes int, last rune) {
+	r.buf.Write(b)
+	if r.capture {
+		r.raw = append(r.raw, b...)
+	}
+	r.column += runes
+	r.lineBytes += len(b)
+	r.offset += int64(len(b))
+	r.lastRune = last
+	r.r.Discard(len(b))
+}
+
+// limitBytes returns the first n bytes of b, or none if n is negative.
+func limitBytes(b []byte, n int) []byte {
+	if n < 0 {
+		n = 0
+	}
+	if len(b) > n {
+		return b[:n]
+	}
+	return b
+}
+
+// isIRIByte reports whether b may appear unescaped in an IRI and needs no further checks.
+func isIRIByte(b byte) bool {
+	if b <= 0x20 || b >= utf8.RuneSelf {
+		return false
+	}
+	switch b {
+	case '<', '>', '"', '{', '}', '|', '^', '`', '\\':
+		return false
+	}
+	return true
+}
+
+// isLiteralByte reports whether b may appear unescaped in a literal and needs no further checks.
+func isLiteralByte(b byte) bool {
+	return b < utf8.RuneSelf && b != '"' && b != '\\' && b != '\n' && b != '\r'
+}
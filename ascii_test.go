@@ -0,0 +1,100 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestScanASCII(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  Quad
+	}{
+		{
+			name:  "ascii",
+			input: "<http://example.org/resource/subject> <http://example.org/p> \"a long plain literal value\" <http://example.org/g> .\n",
+			want:  Quad{S: rdf.IRI("http://example.org/resource/subject"), P: rdf.IRI("http://example.org/p"), O: rdf.Literal("a long plain literal value"), G: rdf.IRI("http://example.org/g")},
+		},
+		{
+			name:  "multibyte",
+			input: "<http://example.org/café/ünïcode> <http://example.org/p> \"naïve café – résumé\"@fr .\n",
+			want:  Quad{S: rdf.IRI("http://example.org/café/ünïcode"), P: rdf.IRI("http://example.org/p"), O: rdf.LiteralWithLanguage("naïve café – résumé", "fr")},
+		},
+		{
+			name:  "escapes",
+			input: "<http://example.org/\\u0041BC> <http://example.org/p> \"tab\\there \\\"quoted\\\" \\u00E9nd\" .\n",
+			want:  Quad{S: rdf.IRI("http://example.org/ABC"), P: rdf.IRI("http://example.org/p"), O: rdf.Literal("tab\there \"quoted\" énd")},
+		},
+		{
+			name:  "invalid utf-8",
+			input: "<http://example.org/s> <http://example.org/p> \"caf\xe9 – ok\" .\n",
+			want:  Quad{S: rdf.IRI("http://example.org/s"), P: rdf.IRI("http://example.org/p"), O: rdf.Literal("caf\uFFFD – ok")},
+		},
+	}
+
+	for _, tc := range testCases {
+		// Small buffers split terms across reads of the underlying reader
+		for _, size := range []int{16, 17, 64, 4096} {
+			r := NewReaderSize(strings.NewReader(tc.input), size)
+			if !r.Next() {
+				t.Fatalf("%s/%d: got unexpected error %q", tc.name, size, r.Err())
+			}
+			if r.Quad() != tc.want {
+				t.Errorf("%s/%d: got %s, wanted %s", tc.name, size, r.Quad(), tc.want)
+			}
+		}
+	}
+}
+
+func TestScanASCIIPosition(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"value\" .\n<http://example.org/s> <http://example.org/p> \"café value\" bad"
+	r := NewReaderSize(strings.NewReader(input), 16)
+	for r.Next() {
+	}
+	var perr *ParseError
+	if !errors.As(r.Err(), &perr) {
+		t.Fatalf("got error %v, wanted a ParseError", r.Err())
+	}
+	if perr.Line != 2 || perr.Column != 59 {
+		t.Errorf("got error at line %d column %d, wanted line 2 column 59", perr.Line, perr.Column)
+	}
+}
+
+func TestScanASCIILimits(t *testing.T) {
+	input := "<http://example.org/s> <http://example.org/p> \"" + strings.Repeat("x", 100) + "\" .\n"
+	multibyte := "<http://example.org/s> <http://example.org/p> \"" + strings.Repeat("é", 50) + "\" .\n"
+	testCases := []struct {
+		input string
+		opt   Option
+		err   error
+	}{
+		{opt: WithMaxLiteralLength(99), err: ErrLiteralTooLong},
+		{opt: WithMaxLiteralLength(100)},
+		{input: multibyte, opt: WithMaxLiteralLength(99), err: ErrLiteralTooLong},
+		{input: multibyte, opt: WithMaxLiteralLength(100)},
+		{input: multibyte, opt: WithMaxStatementLength(80), err: ErrStatementTooLong},
+		{opt: WithMaxIRILength(19), err: ErrIRITooLong},
+		{opt: WithMaxIRILength(20)},
+		{opt: WithMaxStatementLength(80), err: ErrStatementTooLong},
+	}
+	for i, tc := range testCases {
+		if tc.input == "" {
+			tc.input = input
+		}
+		r := NewReader(strings.NewReader(tc.input), tc.opt)
+		for r.Next() {
+		}
+		if !errors.Is(r.Err(), tc.err) && !(tc.err == nil && r.Err() == nil) {
+			t.Errorf("%d: got error %v, wanted %v", i, r.Err(), tc.err)
+		}
+	}
+}
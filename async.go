@@ -0,0 +1,135 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrWriterClosed is the error returned when writing to an AsyncWriter that has been closed.
+var ErrWriterClosed = errors.New("writer closed")
+
+// An AsyncWriter accepts quads into a bounded queue and writes them using a Writer on a background goroutine,
+// so producers are not stalled by a slow destination until the queue is full. Serialization and any work done
+// by the Writer's destination, such as compression by a gzip.Writer, happen on the background goroutine.
+//
+// Errors encountered by the background goroutine are reported by the next call to Write, Flush or Close.
+// An AsyncWriter may be used by multiple goroutines simultaneously.
+type AsyncWriter struct {
+	w     *Writer
+	queue chan asyncItem
+	done  chan struct{}
+
+	mu     sync.RWMutex // guards closed and sends to queue
+	closed bool
+
+	errMu sync.Mutex // guards err
+	err   error
+}
+
+// asyncItem is a quad to be written or, if flushed is non-nil, a request to flush the Writer.
+type asyncItem struct {
+	q       Quad
+	flushed chan struct{}
+}
+
+// NewAsyncWriter returns a new AsyncWriter that writes to w, queueing at most size quads.
+func NewAsyncWriter(w *Writer, size int) *AsyncWriter {
+	if size < 0 {
+		size = 0
+	}
+	a := &AsyncWriter{
+		w:     w,
+		queue: make(chan asyncItem, size),
+		done:  make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+	for item := range a.queue {
+		if item.flushed != nil {
+			a.w.Flush()
+			a.setErr(a.w.Error())
+			close(item.flushed)
+			continue
+		}
+		if a.Err() != nil {
+			// Discard remaining quads after the first error
+			continue
+		}
+		a.setErr(a.w.Write(item.q))
+	}
+	a.w.Flush()
+	a.setErr(a.w.Error())
+}
+
+func (a *AsyncWriter) setErr(err error) {
+	if err == nil {
+		return
+	}
+	a.errMu.Lock()
+	if a.err == nil {
+		a.err = err
+	}
+	a.errMu.Unlock()
+}
+
+// Err returns the first error encountered while writing, if any.
+func (a *AsyncWriter) Err() error {
+	a.errMu.Lock()
+	defer a.errMu.Unlock()
+	return a.err
+}
+
+// Write queues q to be written, blocking while the queue is full. It returns the first error encountered while
+// writing previously queued quads, in which case q is not queued.
+func (a *AsyncWriter) Write(q Quad) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return ErrWriterClosed
+	}
+	if err := a.Err(); err != nil {
+		return err
+	}
+	a.queue <- asyncItem{q: q}
+	return nil
+}
+
+// Flush waits until all queued quads have been written and flushes the Writer. It returns the first error
+// encountered while writing.
+func (a *AsyncWriter) Flush() error {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return ErrWriterClosed
+	}
+	flushed := make(chan struct{})
+	a.queue <- asyncItem{flushed: flushed}
+	a.mu.RUnlock()
+
+	<-flushed
+	return a.Err()
+}
+
+// Close waits until all queued quads have been written, flushes the Writer and stops the background
+// goroutine. It does not close the Writer's underlying io.Writer. It returns the first error encountered
+// while writing.
+func (a *AsyncWriter) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+	return a.Err()
+}
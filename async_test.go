@@ -0,0 +1,66 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+	a := NewAsyncWriter(NewWriter(&buf), 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, tc := range messageCases {
+				if err := a.Write(tc.quad); err != nil {
+					t.Errorf("got unexpected error %q", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := a.Flush(); err != nil {
+		t.Fatalf("flush: got unexpected error %q", err)
+	}
+	if got, want := strings.Count(buf.String(), "\n"), 4*len(messageCases); got != want {
+		t.Errorf("got %d lines, wanted %d", got, want)
+	}
+
+	if err := a.Close(); err != nil {
+		t.Fatalf("close: got unexpected error %q", err)
+	}
+	if err := a.Write(messageCases[0].quad); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("got error %v after close, wanted %v", err, ErrWriterClosed)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestAsyncWriterError(t *testing.T) {
+	a := NewAsyncWriter(NewWriter(failingWriter{}), 1)
+	if err := a.Write(messageCases[0].quad); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if err := a.Flush(); err == nil {
+		t.Errorf("flush: got no error, wanted write failure")
+	}
+	if err := a.Close(); err == nil {
+		t.Errorf("close: got no error, wanted write failure")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+)
+
+// WithBaseIRI configures the Reader to resolve relative IRIs against base, following RFC 3986, instead of
+// failing with ErrRelativeIRI. Relative IRIs are not permitted by N-Quads but are emitted by some tools.
+// The base should be an absolute IRI; a relative IRI that does not resolve to an absolute IRI is still
+// reported as an error.
+func WithBaseIRI(base string) Option {
+	return func(r *Reader) {
+		r.base = base
+	}
+}
+
+// WithRelativeIRIs configures the Reader to accept relative IRIs and return them unchanged, instead of failing
+// with ErrRelativeIRI, for pipelines that resolve them at a later stage. If a base IRI is also configured
+// using WithBaseIRI, relative IRIs are resolved against it instead.
+func WithRelativeIRIs() Option {
+	return func(r *Reader) {
+		r.allowRelative = true
+	}
+}
+
+// checkIRI returns iri if it is absolute or, if a base IRI has been configured, iri resolved against the
+// base. Otherwise it returns iri if relative IRIs are allowed or ErrRelativeIRI if not. If strict IRI
+// checking is enabled, ErrInvalidIRI is returned for an IRI that does not conform to RFC 3987.
+func (r *Reader) checkIRI(iri string) (string, error) {
+	relative := false
+	if !isAbsoluteIRI(iri) {
+		resolved := ""
+		if r.base != "" {
+			resolved = resolveIRI(r.base, iri)
+		}
+		if isAbsoluteIRI(resolved) {
+			iri = resolved
+		} else if r.allowRelative {
+			relative = true
+		} else {
+			return iri, r.wrap(ErrRelativeIRI)
+		}
+	}
+	if (r.strictIRIs || r.onWarning != nil) && !isValidIRI(iri, relative) {
+		if r.strictIRIs {
+			return iri, r.wrap(ErrInvalidIRI)
+		}
+		r.warn(ErrUnwiseIRI)
+	}
+	return iri, nil
+}
+
+// iriParts holds the components of an IRI reference as described by RFC 3986 section 3.
+type iriParts struct {
+	scheme, authority, path, query, fragment       string
+	hasScheme, hasAuthority, hasQuery, hasFragment bool
+}
+
+func splitIRI(s string) iriParts {
+	var p iriParts
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		p.fragment, p.hasFragment = s[i+1:], true
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		p.query, p.hasQuery = s[i+1:], true
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, ':'); i > 0 && !strings.ContainsAny(s[:i], "/") {
+		p.scheme, p.hasScheme = s[:i], true
+		s = s[i+1:]
+	}
+	if strings.HasPrefix(s, "//") {
+		s = s[2:]
+		i := strings.IndexByte(s, '/')
+		if i < 0 {
+			i = len(s)
+		}
+		p.authority, p.hasAuthority = s[:i], true
+		s = s[i:]
+	}
+	p.path = s
+	return p
+}
+
+func (p iriParts) String() string {
+	var b strings.Builder
+	if p.hasScheme {
+		b.WriteString(p.scheme)
+		b.WriteByte(':')
+	}
+	if p.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(p.authority)
+	}
+	b.WriteString(p.path)
+	if p.hasQuery {
+		b.WriteByte('?')
+		b.WriteString(p.query)
+	}
+	if p.hasFragment {
+		b.WriteByte('#')
+		b.WriteString(p.fragment)
+	}
+	return b.String()
+}
+
+// resolveIRI resolves the IRI reference ref against base using the algorithm of RFC 3986 section 5.2.2.
+func resolveIRI(base, ref string) string {
+	b, r := splitIRI(base), splitIRI(ref)
+	var t iriParts
+
+	switch {
+	case r.hasScheme:
+		t = r
+		t.path = removeDotSegments(r.path)
+	case r.hasAuthority:
+		t = r
+		t.path = removeDotSegments(r.path)
+		t.scheme, t.hasScheme = b.scheme, b.hasScheme
+	default:
+		t.scheme, t.hasScheme = b.scheme, b.hasScheme
+		t.authority, t.hasAuthority = b.authority, b.hasAuthority
+		switch {
+		case r.path == "":
+			t.path = b.path
+			if r.hasQuery {
+				t.query, t.hasQuery = r.query, true
+			} else {
+				t.query, t.hasQuery = b.query, b.hasQuery
+			}
+		case strings.HasPrefix(r.path, "/"):
+			t.path = removeDotSegments(r.path)
+			t.query, t.hasQuery = r.query, r.hasQuery
+		default:
+			t.path = removeDotSegments(mergePaths(b, r.path))
+			t.query, t.hasQuery = r.query, r.hasQuery
+		}
+	}
+	t.fragment, t.hasFragment = r.fragment, r.hasFragment
+	return t.String()
+}
+
+// mergePaths merges a relative path with the path of base as described by RFC 3986 section 5.2.3.
+func mergePaths(base iriParts, path string) string {
+	if base.hasAuthority && base.path == "" {
+		return "/" + path
+	}
+	if i := strings.LastIndexByte(base.path, '/'); i >= 0 {
+		return base.path[:i+1] + path
+	}
+	return path
+}
+
+// removeDotSegments removes the special "." and ".." segments from path as described by RFC 3986
+// section 5.2.4.
+func removeDotSegments(path string) string {
+	var out []string
+	for path != "" {
+		switch {
+		case strings.HasPrefix(path, "../"):
+			path = path[3:]
+		case strings.HasPrefix(path, "./"):
+			path = path[2:]
+		case strings.HasPrefix(path, "/./"):
+			path = path[2:]
+		case path == "/.":
+			path = "/"
+		case strings.HasPrefix(path, "/../"):
+			path = path[3:]
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case path == "/..":
+			path = "/"
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case path == "." || path == "..":
+			path = ""
+		default:
+			i := strings.IndexByte(path[1:], '/')
+			if i < 0 {
+				out = append(out, path)
+				path = ""
+			} else {
+				out = append(out, path[:i+1])
+				path = path[i+1:]
+			}
+		}
+	}
+	return strings.Join(out, "")
+}
@@ -0,0 +1,118 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestResolveIRI(t *testing.T) {
+	// Examples from RFC 3986 section 5.4
+	base := "http://a/b/c/d;p?q"
+	testCases := []struct {
+		ref  string
+		want string
+	}{
+		{"g:h", "g:h"},
+		{"g", "http://a/b/c/g"},
+		{"./g", "http://a/b/c/g"},
+		{"g/", "http://a/b/c/g/"},
+		{"/g", "http://a/g"},
+		{"//g", "http://g"},
+		{"?y", "http://a/b/c/d;p?y"},
+		{"g?y", "http://a/b/c/g?y"},
+		{"#s", "http://a/b/c/d;p?q#s"},
+		{"g#s", "http://a/b/c/g#s"},
+		{"g?y#s", "http://a/b/c/g?y#s"},
+		{";x", "http://a/b/c/;x"},
+		{"g;x", "http://a/b/c/g;x"},
+		{"g;x?y#s", "http://a/b/c/g;x?y#s"},
+		{"", "http://a/b/c/d;p?q"},
+		{".", "http://a/b/c/"},
+		{"./", "http://a/b/c/"},
+		{"..", "http://a/b/"},
+		{"../", "http://a/b/"},
+		{"../g", "http://a/b/g"},
+		{"../..", "http://a/"},
+		{"../../", "http://a/"},
+		{"../../g", "http://a/g"},
+		{"../../../g", "http://a/g"},
+		{"../../../../g", "http://a/g"},
+		{"/./g", "http://a/g"},
+		{"/../g", "http://a/g"},
+		{"g.", "http://a/b/c/g."},
+		{".g", "http://a/b/c/.g"},
+		{"g..", "http://a/b/c/g.."},
+		{"..g", "http://a/b/c/..g"},
+		{"./../g", "http://a/b/g"},
+		{"./g/.", "http://a/b/c/g/"},
+		{"g/./h", "http://a/b/c/g/h"},
+		{"g/../h", "http://a/b/c/h"},
+		{"g;x=1/./y", "http://a/b/c/g;x=1/y"},
+		{"g;x=1/../y", "http://a/b/c/y"},
+		{"g?y/./x", "http://a/b/c/g?y/./x"},
+		{"g#s/../x", "http://a/b/c/g#s/../x"},
+		{"café", "http://a/b/c/café"},
+	}
+
+	for _, tc := range testCases {
+		if got := resolveIRI(base, tc.ref); got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.ref, got, tc.want)
+		}
+	}
+}
+
+func TestWithBaseIRI(t *testing.T) {
+	input := `<s> <#p> <../o> <g> .
+<http://example/s> <http://example/p> "1"^^<dt> .
+`
+	want := []Quad{
+		{S: rdf.IRI("http://example/data/s"), P: rdf.IRI("http://example/data/doc#p"), O: rdf.IRI("http://example/o"), G: rdf.IRI("http://example/data/g")},
+		{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithDatatype("1", "http://example/data/dt")},
+	}
+
+	r := NewReader(strings.NewReader(input), WithBaseIRI("http://example/data/doc"))
+	var got []Quad
+	for r.Next() {
+		got = append(got, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %s, wanted %s", got[i], want[i])
+		}
+	}
+}
+
+func TestWithRelativeIRIs(t *testing.T) {
+	input := `<s> <http://example/p> "1"^^<dt> <g> .`
+	want := Quad{S: rdf.IRI("s"), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithDatatype("1", "dt"), G: rdf.IRI("g")}
+
+	r := NewReader(strings.NewReader(input), WithRelativeIRIs())
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if r.Quad() != want {
+		t.Errorf("got %s, wanted %s", r.Quad(), want)
+	}
+
+	// A base IRI takes precedence
+	r = NewReader(strings.NewReader(input), WithRelativeIRIs(), WithBaseIRI("http://example/"))
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if got := r.Quad().S; got != rdf.IRI("http://example/s") {
+		t.Errorf("got subject %q, wanted %q", got.Value, "http://example/s")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"container/list"
+	"errors"
+	"strconv"
+
+	"github.com/iand/gordf"
+)
+
+// ErrTooManyBlankNodes is the error returned when a BlankNodeMap has reached its limit on the number of
+// labels it holds.
+var ErrTooManyBlankNodes = errors.New("too many distinct blank node labels")
+
+// A BlankNodeMap consistently assigns new labels to blank node labels, as required when relabeling,
+// skolemizing or merging. The same label is mapped to the same new label for as long as it is held by
+// the map.
+//
+// The number of labels held can be bounded by setting MaxLabels so that input with an unbounded number of
+// distinct labels cannot exhaust memory. Once the limit is reached Map returns ErrTooManyBlankNodes unless
+// Evict is set, in which case the least recently used label is discarded to make room. A discarded label
+// that is seen again is assigned a fresh label, so eviction is only appropriate when all uses of a blank
+// node are close together in the input.
+type BlankNodeMap struct {
+	MaxLabels int                // the maximum number of labels held, unlimited if zero
+	Evict     bool               // true to discard the least recently used label instead of failing
+	NewLabel  func(n int) string // returns the label assigned to the nth distinct label, b0, b1 and so on if nil
+
+	labels map[string]*list.Element
+	lru    list.List // of *blankNodeEntry, most recently used at the front
+	n      int       // the number of labels assigned
+}
+
+type blankNodeEntry struct {
+	label  string
+	mapped string
+}
+
+// Map returns the new label assigned to label, assigning one if label has not been seen before.
+func (m *BlankNodeMap) Map(label string) (string, error) {
+	if m.labels == nil {
+		m.labels = make(map[string]*list.Element)
+	}
+
+	if el, ok := m.labels[label]; ok {
+		m.lru.MoveToFront(el)
+		return el.Value.(*blankNodeEntry).mapped, nil
+	}
+
+	if m.MaxLabels > 0 && len(m.labels) >= m.MaxLabels {
+		if !m.Evict {
+			return "", ErrTooManyBlankNodes
+		}
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.labels, oldest.Value.(*blankNodeEntry).label)
+	}
+
+	var mapped string
+	if m.NewLabel != nil {
+		mapped = m.NewLabel(m.n)
+	} else {
+		mapped = "b" + strconv.Itoa(m.n)
+	}
+	m.n++
+
+	m.labels[label] = m.lru.PushFront(&blankNodeEntry{label: label, mapped: mapped})
+	return mapped, nil
+}
+
+// Len returns the number of labels currently held by the map.
+func (m *BlankNodeMap) Len() int {
+	return len(m.labels)
+}
+
+// Reset discards all labels held by the map and restarts label assignment.
+func (m *BlankNodeMap) Reset() {
+	m.labels = nil
+	m.lru.Init()
+	m.n = 0
+}
+
+// WithBlankNodeMapper configures the Reader to replace the label of each blank node it reads with the
+// result of calling fn with the label. This allows blank nodes from several sources to be kept distinct
+// when they are merged, for example by adding a prefix for each source. The label returned by fn is used
+// as given and should be a valid blank node label if the quads are to be written as N-Quads.
+func WithBlankNodeMapper(fn func(label string) string) Option {
+	return func(r *Reader) {
+		r.mapBlank = fn
+	}
+}
+
+// blankNode returns a blank node term for the label held in r.buf, which starts at the given offset in the
+// current line.
+func (r *Reader) blankNode(start int) rdf.Term {
+	if r.mapBlank != nil && !r.discard {
+		return rdf.Blank(r.mapBlank(r.buf.String()))
+	}
+	return rdf.Blank(r.spanText(start, start+r.buf.Len()))
+}
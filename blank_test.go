@@ -0,0 +1,117 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestBlankNodeMap(t *testing.T) {
+	m := &BlankNodeMap{}
+	for _, tc := range []struct {
+		label string
+		want  string
+	}{
+		{"x", "b0"},
+		{"y", "b1"},
+		{"x", "b0"},
+		{"z", "b2"},
+		{"y", "b1"},
+	} {
+		got, err := m.Map(tc.label)
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, wanted %q", tc.label, got, tc.want)
+		}
+	}
+	if m.Len() != 3 {
+		t.Errorf("got length %d, wanted 3", m.Len())
+	}
+
+	m.Reset()
+	if got, _ := m.Map("z"); got != "b0" {
+		t.Errorf("got %q after reset, wanted b0", got)
+	}
+}
+
+func TestBlankNodeMapLimit(t *testing.T) {
+	m := &BlankNodeMap{MaxLabels: 2}
+	m.Map("x")
+	m.Map("y")
+	if _, err := m.Map("x"); err != nil {
+		t.Errorf("got unexpected error %q for existing label", err)
+	}
+	if _, err := m.Map("z"); !errors.Is(err, ErrTooManyBlankNodes) {
+		t.Errorf("got error %v, wanted %v", err, ErrTooManyBlankNodes)
+	}
+}
+
+func TestBlankNodeMapEvict(t *testing.T) {
+	m := &BlankNodeMap{
+		MaxLabels: 2,
+		Evict:     true,
+		NewLabel:  func(n int) string { return "n" + string(rune('a'+n)) },
+	}
+
+	for _, tc := range []struct {
+		label string
+		want  string
+	}{
+		{"x", "na"},
+		{"y", "nb"},
+		{"x", "na"}, // x is now most recently used
+		{"z", "nc"}, // evicts y
+		{"x", "na"},
+		{"y", "nd"}, // y was evicted so is assigned a fresh label, evicting z
+	} {
+		got, err := m.Map(tc.label)
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, wanted %q", tc.label, got, tc.want)
+		}
+	}
+	if m.Len() != 2 {
+		t.Errorf("got length %d, wanted 2", m.Len())
+	}
+}
+
+func TestBlankNodeMapper(t *testing.T) {
+	input := "_:a <http://example/p> _:b .\n" +
+		"_:b <http://example/p> \"1\" _:g.\n" +
+		"<http://example/s> <http://example/p> _:a.b.\n"
+	want := []Quad{
+		{S: rdf.Blank("src1_a"), P: rdf.IRI("http://example/p"), O: rdf.Blank("src1_b")},
+		{S: rdf.Blank("src1_b"), P: rdf.IRI("http://example/p"), O: rdf.Literal("1"), G: rdf.Blank("src1_g")},
+		{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Blank("src1_a.b")},
+	}
+
+	r := NewReader(strings.NewReader(input), WithBlankNodeMapper(func(label string) string {
+		return "src1_" + label
+	}))
+	var got []Quad
+	for r.Next() {
+		got = append(got, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %v, wanted %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrByteOrderMark is the error returned in ParseError.Err when the input starts with a byte order mark and
+// the Reader was configured using WithRejectBOM.
+var ErrByteOrderMark = errors.New("unexpected byte order mark")
+
+// byteOrderMark is the character that, encoded as UTF-8, some tools write at the start of UTF-8 files.
+const byteOrderMark = '\uFEFF'
+
+// WithRejectBOM configures the Reader to fail with ErrByteOrderMark if the input starts with a UTF-8 byte
+// order mark. By default a byte order mark at the start of the input is skipped, as written by many Windows
+// tools, even though N-Quads documents should not contain one.
+func WithRejectBOM() Option {
+	return func(r *Reader) {
+		r.rejectBOM = true
+	}
+}
+
+// skipBOM skips a byte order mark at the start of the input, or returns an error if r.rejectBOM is set.
+// The byte order mark does not count towards the column of the first statement.
+func (r *Reader) skipBOM() error {
+	r1, err := r.readRune()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	if r1 != byteOrderMark {
+		return r.unreadRune()
+	}
+	if r.rejectBOM {
+		return r.wrap(ErrByteOrderMark)
+	}
+	r.column = -1
+	r.lineBytes = 0
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestByteOrderMark(t *testing.T) {
+	const bom = "\xEF\xBB\xBF"
+	statement := "<http://example/s> <http://example/p> \"1\" .\n"
+
+	testCases := []struct {
+		name   string
+		input  string
+		opts   []Option
+		want   int
+		err    error
+		line   int64
+		column int64
+		offset int64
+	}{
+		{name: "none", input: statement, want: 1},
+		{name: "skipped", input: bom + statement + statement, want: 2, offset: 3},
+		{name: "before comment", input: bom + "# comment\n" + statement, want: 1, line: 2, offset: 13},
+		{name: "only", input: bom, want: 0},
+		{name: "empty", input: "", want: 0},
+		{name: "not at start", input: statement + bom + statement, want: 1, err: ErrUnexpectedCharacter},
+		{name: "rejected", input: bom + statement, opts: []Option{WithRejectBOM()}, err: ErrByteOrderMark},
+		{name: "rejected absent", input: statement, opts: []Option{WithRejectBOM()}, want: 1},
+		{name: "charset", input: "\xEF\xBB\xBF" + statement, opts: []Option{WithCharset(Latin1)}, err: ErrUnexpectedCharacter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.input), tc.opts...)
+			n := 0
+			for r.Next() {
+				if n == 0 {
+					line, column, offset := r.Position()
+					if tc.line == 0 {
+						tc.line = 1
+					}
+					if line != tc.line || column != tc.column || offset != tc.offset {
+						t.Errorf("got first quad at line %d column %d offset %d, wanted line %d column %d offset %d", line, column, offset, tc.line, tc.column, tc.offset)
+					}
+				}
+				n++
+			}
+			if !errors.Is(r.Err(), tc.err) {
+				t.Errorf("got error %v, wanted %v", r.Err(), tc.err)
+			}
+			if n != tc.want {
+				t.Errorf("got %d quads, wanted %d", n, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"time"
+)
+
+// A BulkSink receives batches of quads from a BulkLoader, typically to load them into a store.
+type BulkSink interface {
+	// AddBatch adds a batch of quads to the sink. The sink must not retain the slice after AddBatch returns.
+	AddBatch([]Quad) error
+
+	// Flush commits any quads added to the sink since the last call to Flush.
+	Flush() error
+}
+
+// Defaults used by a BulkLoader when the corresponding field is zero.
+const (
+	DefaultBatchSize    = 1000
+	DefaultRetryBackoff = 100 * time.Millisecond
+)
+
+// A BulkLoader feeds quads read from a Reader to a BulkSink in batches.
+//
+// A batch is passed to the sink when it holds BatchSize quads or approximately BatchBytes bytes of term data,
+// whichever is reached first. When FlushInterval is positive, any partial batch is passed to the sink and the
+// sink is flushed once that interval has elapsed since the last flush, bounding how long a quad can wait
+// before reaching the sink. The sink is always flushed once the input has been exhausted.
+//
+// Failed calls to the sink are retried up to MaxRetries times, waiting RetryBackoff before the first retry and
+// doubling the wait before each subsequent one.
+type BulkLoader struct {
+	BatchSize     int           // maximum number of quads in a batch, DefaultBatchSize if zero
+	BatchBytes    int           // approximate maximum size of the terms in a batch, unlimited if zero
+	FlushInterval time.Duration // maximum time between flushes, disabled if zero
+	MaxRetries    int           // number of times a failed call to the sink is retried
+	RetryBackoff  time.Duration // wait before the first retry, DefaultRetryBackoff if zero
+}
+
+// Load reads all quads from r and feeds them to sink, returning the number of quads added to the sink.
+// It stops at the first error reported by r or, once retries are exhausted, by sink. Load does not return
+// until it has stopped using r.
+func (l *BulkLoader) Load(sink BulkSink, r *Reader) (int64, error) {
+	batchSize := l.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	quads := make(chan Quad, batchSize)
+	done := make(chan struct{})
+	defer func() {
+		// Stop the reading goroutine and wait for it to finish so that r is not in use once Load returns.
+		close(done)
+		for range quads {
+		}
+	}()
+
+	// Read in a separate goroutine so time-based flushes happen even while the reader is blocked.
+	var readErr error
+	go func() {
+		defer close(quads)
+		for r.Next() {
+			select {
+			case quads <- r.Quad():
+			case <-done:
+				return
+			}
+		}
+		readErr = r.Err()
+	}()
+
+	var tick <-chan time.Time
+	if l.FlushInterval > 0 {
+		ticker := time.NewTicker(l.FlushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var n int64
+	batch := make([]Quad, 0, batchSize)
+	batchBytes := 0
+
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := l.retry(func() error { return sink.AddBatch(batch) }); err != nil {
+			return err
+		}
+		n += int64(len(batch))
+		batch = batch[:0]
+		batchBytes = 0
+		return nil
+	}
+
+	for {
+		select {
+		case q, ok := <-quads:
+			if !ok {
+				if readErr != nil {
+					return n, readErr
+				}
+				if err := send(); err != nil {
+					return n, err
+				}
+				return n, l.retry(sink.Flush)
+			}
+			batch = append(batch, q)
+			batchBytes += quadSize(q)
+			if len(batch) >= batchSize || (l.BatchBytes > 0 && batchBytes >= l.BatchBytes) {
+				if err := send(); err != nil {
+					return n, err
+				}
+			}
+		case <-tick:
+			if err := send(); err != nil {
+				return n, err
+			}
+			if err := l.retry(sink.Flush); err != nil {
+				return n, err
+			}
+		}
+	}
+}
+
+// retry calls fn until it succeeds or l.MaxRetries retries have failed, returning the last error.
+func (l *BulkLoader) retry(fn func() error) error {
+	backoff := l.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	err := fn()
+	for i := 0; err != nil && i < l.MaxRetries; i++ {
+		time.Sleep(backoff)
+		backoff *= 2
+		err = fn()
+	}
+	return err
+}
+
+// quadSize returns the approximate number of bytes occupied by the terms of q.
+func quadSize(q Quad) int {
+	return len(q.S.Value) + len(q.P.Value) + len(q.O.Value) + len(q.O.Language) + len(q.O.Datatype) + len(q.G.Value)
+}
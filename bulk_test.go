@@ -0,0 +1,151 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	batches  [][]Quad
+	flushes  int
+	failures int // number of calls to AddBatch that should fail before succeeding
+	limit    int // number of batches accepted before every call to AddBatch fails, unlimited if zero
+}
+
+func (s *recordingSink) AddBatch(quads []Quad) error {
+	if s.limit > 0 && len(s.batches) >= s.limit {
+		return errors.New("sink full")
+	}
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("temporary failure")
+	}
+	s.batches = append(s.batches, append([]Quad(nil), quads...))
+	return nil
+}
+
+func (s *recordingSink) Flush() error {
+	s.flushes++
+	return nil
+}
+
+func generateQuads(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "<http://example/s%d> <http://example/p> \"%d\" .\n", i, i)
+	}
+	return sb.String()
+}
+
+func TestBulkLoaderBatchSize(t *testing.T) {
+	sink := &recordingSink{}
+	l := &BulkLoader{BatchSize: 4}
+
+	n, err := l.Load(sink, NewReader(strings.NewReader(generateQuads(10))))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if n != 10 {
+		t.Errorf("got %d quads loaded, wanted 10", n)
+	}
+
+	sizes := []int{}
+	for _, b := range sink.batches {
+		sizes = append(sizes, len(b))
+	}
+	if fmt.Sprint(sizes) != "[4 4 2]" {
+		t.Errorf("got batch sizes %v, wanted [4 4 2]", sizes)
+	}
+	if sink.flushes != 1 {
+		t.Errorf("got %d flushes, wanted 1", sink.flushes)
+	}
+}
+
+func TestBulkLoaderBatchBytes(t *testing.T) {
+	sink := &recordingSink{}
+	// Each generated quad has at least 30 bytes of term data
+	l := &BulkLoader{BatchBytes: 60}
+
+	if _, err := l.Load(sink, NewReader(strings.NewReader(generateQuads(6)))); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if len(sink.batches) != 3 {
+		t.Errorf("got %d batches, wanted 3", len(sink.batches))
+	}
+}
+
+func TestBulkLoaderFlushInterval(t *testing.T) {
+	g := &growingReader{}
+	g.WriteString(generateQuads(2))
+
+	sink := &recordingSink{}
+	l := &BulkLoader{FlushInterval: 10 * time.Millisecond}
+
+	errc := make(chan error)
+	go func() {
+		_, err := l.Load(sink, NewReader(g, WithFollow(time.Millisecond)))
+		errc <- err
+	}()
+
+	// The loader should flush the partial batch while the reader waits for more data. Finish
+	// the load by supplying invalid input.
+	time.Sleep(100 * time.Millisecond)
+	g.WriteString("not a quad\n")
+	if err := <-errc; !errors.Is(err, ErrUnexpectedCharacter) {
+		t.Fatalf("got error %v, wanted %v", err, ErrUnexpectedCharacter)
+	}
+
+	if len(sink.batches) == 0 || len(sink.batches[0]) != 2 {
+		t.Errorf("got batches %v, wanted a batch of 2 quads", sink.batches)
+	}
+	if sink.flushes == 0 {
+		t.Errorf("got no flushes")
+	}
+}
+
+func TestBulkLoaderRetry(t *testing.T) {
+	sink := &recordingSink{failures: 2}
+	l := &BulkLoader{MaxRetries: 2, RetryBackoff: time.Millisecond}
+
+	n, err := l.Load(sink, NewReader(strings.NewReader(generateQuads(3))))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if n != 3 {
+		t.Errorf("got %d quads loaded, wanted 3", n)
+	}
+
+	sink = &recordingSink{failures: 3}
+	if _, err := l.Load(sink, NewReader(strings.NewReader(generateQuads(3)))); err == nil {
+		t.Errorf("got no error, wanted failure after retries exhausted")
+	}
+}
+
+func TestBulkLoaderSinkFailure(t *testing.T) {
+	sink := &recordingSink{limit: 2}
+	l := &BulkLoader{BatchSize: 2}
+
+	r := NewReader(strings.NewReader(generateQuads(1000)))
+	n, err := l.Load(sink, r)
+	if err == nil {
+		t.Fatalf("got no error, wanted sink failure")
+	}
+	if n != 4 {
+		t.Errorf("got %d quads loaded, wanted 4", n)
+	}
+
+	// The reader must no longer be in use by the loader, which the race detector checks.
+	for r.Next() {
+	}
+	if err := r.Err(); err != nil {
+		t.Errorf("got unexpected reader error %q", err)
+	}
+}
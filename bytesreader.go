@@ -0,0 +1,69 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// NewBytesReader returns a new Reader, configured using the supplied options, that reads the quads held in
+// b without copying it. The strings of IRIs and literal values that contain no escapes share memory with
+// b rather than being copied, which avoids most of the allocations made while parsing, so b must not be
+// modified while the quads read are in use.
+//
+// If the Reader is configured using WithCharset to read an encoding other than UTF-8, b is decoded as it
+// is read and terms are copied as they are by NewReader.
+func NewBytesReader(b []byte, opts ...Option) *Reader {
+	return newMemoryReader(b, true, opts...)
+}
+
+// newMemoryReader returns a new Reader, configured using the supplied options, that reads the quads held in
+// b. If shared is true the strings of terms may share memory with b.
+func newMemoryReader(b []byte, shared bool, opts ...Option) *Reader {
+	r := newReader(opts)
+	if r.charset != UTF8 {
+		return NewReader(bytes.NewReader(b), opts...)
+	}
+	r.lines.data = b
+	if r.maxLine > 0 {
+		r.lines.max = r.maxLine + utf8.UTFMax
+	}
+	r.shared = shared
+	return r
+}
+
+// spanText returns the text of the term that occupies the bytes of the current line from start to end,
+// which has been decoded into r.buf. If the term was decoded unchanged the string refers to the line rather
+// than being copied from r.buf: to the input itself if terms may share memory with it, otherwise to a copy
+// of the line shared by the terms of the statement.
+func (r *Reader) spanText(start, end int) string {
+	if r.discard {
+		return ""
+	}
+	if end > len(r.r.b) {
+		return r.buf.String()
+	}
+	b := r.r.b[start:end]
+	if !bytes.Equal(b, r.buf.Bytes()) {
+		// the term contains escapes or invalid UTF-8 that was replaced
+		return r.buf.String()
+	}
+	if r.shared {
+		return unsafe.String(unsafe.SliceData(b), len(b))
+	}
+	return r.r.text(start, end)
+}
+
+// internedSpan returns the text of a term as described by spanText, or a shared string for it if the Reader
+// was configured using WithInterning.
+func (r *Reader) internedSpan(start, end int) string {
+	if r.interner != nil {
+		return r.internedText()
+	}
+	return r.spanText(start, end)
+}
@@ -0,0 +1,159 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/iand/gordf"
+)
+
+// sharesMemory reports whether the bytes of s lie within b.
+func sharesMemory(s string, b []byte) bool {
+	if len(s) == 0 || len(b) == 0 {
+		return false
+	}
+	p := uintptr(unsafe.Pointer(unsafe.StringData(s)))
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+	return p >= start && p < start+uintptr(len(b))
+}
+
+func TestBytesReader(t *testing.T) {
+	input := datasetInput +
+		"<http://example/s> <http://example/p> \"caf\\u00E9 \\\"quoted\\\"\"@fr .\r\n" +
+		"<http://example/s\\u0031> <http://example/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> <http://example/g> .\r" +
+		"<< <http://example/s> <http://example/p> \"x\" >> <http://example/p> \"ünïcode\" ."
+
+	want, err := ReadAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	b := []byte(input)
+	r := NewBytesReader(b)
+	var got []Quad
+	for r.Next() {
+		got = append(got, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %s, wanted %s", got[i], want[i])
+		}
+	}
+
+	last := len(got) - 1
+	testCases := []struct {
+		name   string
+		s      string
+		shared bool
+	}{
+		{name: "iri", s: got[0].P.Value, shared: true},
+		{name: "literal", s: got[last].O.Value, shared: true},
+		{name: "escaped literal", s: got[last-2].O.Value, shared: false},
+		{name: "escaped iri", s: got[last-1].S.Value, shared: false},
+		{name: "datatype", s: got[last-1].O.Datatype, shared: true},
+	}
+	for _, tc := range testCases {
+		if sharesMemory(tc.s, b) != tc.shared {
+			t.Errorf("%s: %q shares memory with input: %v, wanted %v", tc.name, tc.s, !tc.shared, tc.shared)
+		}
+	}
+}
+
+func TestParseBytesCopies(t *testing.T) {
+	b := []byte("<http://example/s> <http://example/p> \"value\" .\n")
+	quads, err := ParseBytes(b)
+	if err != nil || len(quads) != 1 {
+		t.Fatalf("got %d quads and error %v", len(quads), err)
+	}
+	copy(b, strings.Repeat("x", len(b)))
+	if quads[0].S.Value != "http://example/s" || quads[0].O.Value != "value" {
+		t.Errorf("got %s after modifying input", quads[0])
+	}
+}
+
+func TestBytesReaderOptions(t *testing.T) {
+	input := []byte("<http://example/s> <http://example/p> \"" + strings.Repeat("x", 100) + "\" .\n<http://example/s> <http://example/p> \"y\" .")
+	r := NewBytesReader(input, WithMaxStatementLength(60), WithSkipInvalid(nil), WithInterning())
+	var got []Quad
+	for r.Next() {
+		got = append(got, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != 1 || got[0].O.Value != "y" {
+		t.Errorf("got quads %v, wanted only the second", got)
+	}
+	if sharesMemory(got[0].S.Value, input) {
+		t.Errorf("got subject sharing memory with input, wanted an interned string")
+	}
+}
+
+func TestTermsShareLine(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  Quad
+	}{
+		{
+			name:  "iris",
+			input: "<http://example/s> <http://example/p> <http://example/o> <http://example/g> .\n",
+			want:  Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.IRI("http://example/o"), G: rdf.IRI("http://example/g")},
+		},
+		{
+			name:  "blank nodes",
+			input: "_:b1 <http://example/p> _:b2.\n",
+			want:  Quad{S: rdf.Blank("b1"), P: rdf.IRI("http://example/p"), O: rdf.Blank("b2")},
+		},
+		{
+			name:  "language tag",
+			input: "<http://example/s> <http://example/p> \"chat\"@fr-CA--ltr .\n",
+			want:  Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithLanguage("chat", "fr-CA--ltr")},
+		},
+		{
+			name:  "escapes",
+			input: "<http://example/\\u0073> <http://example/p> \"a\\tb\" .\n",
+			want:  Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal("a\tb")},
+		},
+		{
+			name:  "escapes and invalid utf-8 of the same length",
+			input: "<http://example/s> <http://example/p> \"\\t\\n\xff\" .\n",
+			want:  Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal("\t\n\uFFFD")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, r := range []*Reader{NewReader(strings.NewReader(tc.input)), NewBytesReader([]byte(tc.input))} {
+				if !r.Next() {
+					t.Fatalf("got unexpected error %q", r.Err())
+				}
+				if got := r.Quad(); got != tc.want {
+					t.Errorf("got %s, wanted %s", got, tc.want)
+				}
+			}
+		})
+	}
+
+	input := strings.Repeat("<http://example/s> <http://example/p> \"value\"@en <http://example/g> .\n", 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		r := NewReader(strings.NewReader(input))
+		for r.Next() {
+		}
+	})
+	if perQuad := allocs / 100; perQuad > 1.5 {
+		t.Errorf("got %.2f allocations per quad, wanted at most one", perQuad)
+	}
+}
@@ -0,0 +1,454 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/iand/gordf"
+)
+
+// ErrCanonicalizationLimit is the error returned when canonicalizing a dataset would exceed the limit on the
+// work performed, which protects against inputs crafted to make canonicalization take an unreasonable time.
+var ErrCanonicalizationLimit = errors.New("canonicalization work limit exceeded")
+
+// maxNDegreeCalls limits the number of times the Hash N-Degree Quads algorithm may be invoked while
+// canonicalizing a single dataset.
+const maxNDegreeCalls = 100000
+
+// Canonicalize relabels the blank nodes in quads using the W3C RDF Dataset Canonicalization algorithm
+// (RDFC-1.0, also known as URDNA2015) with SHA-256 as the hash algorithm. Two datasets that are isomorphic
+// produce identical results, which makes the output suitable for signing, hashing and diffing.
+//
+// The returned quads have blank node labels of the form c14n0, c14n1 and so on, contain no duplicates and
+// are ordered by their canonical N-Quads serialization. ErrCanonicalizationLimit is returned if the dataset
+// requires an excessive amount of work to canonicalize.
+func Canonicalize(quads []Quad) ([]Quad, error) {
+	// Duplicates would otherwise be counted again in the hashes of the blank nodes they mention
+	quads = slices.Clone(quads)
+	sortQuads(quads)
+	quads = slices.Compact(quads)
+
+	c := newCanonicalizer(quads)
+	if err := c.run(); err != nil {
+		return nil, err
+	}
+
+	type line struct {
+		text string
+		quad Quad
+	}
+	lines := make([]line, 0, len(quads))
+	for _, q := range c.quads {
+		q.S = c.relabel(q.S)
+		q.O = c.relabel(q.O)
+		q.G = c.relabel(q.G)
+		lines = append(lines, line{text: string(appendCanonicalQuad(nil, q, nil)), quad: q})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].text < lines[j].text })
+
+	out := make([]Quad, 0, len(lines))
+	for i, l := range lines {
+		if i > 0 && l.text == lines[i-1].text {
+			continue
+		}
+		out = append(out, l.quad)
+	}
+	return out, nil
+}
+
+// EqualQuads reports whether a and b hold the same quads once their blank nodes are consistently
+// relabelled, that is whether they are isomorphic datasets. The order of the quads and any duplicates are
+// ignored. Quads that are so complex to canonicalize that ErrCanonicalizationLimit would be returned by
+// Canonicalize are reported as not equal.
+func EqualQuads(a, b []Quad) bool {
+	ca, err := Canonicalize(a)
+	if err != nil {
+		return false
+	}
+	cb, err := Canonicalize(b)
+	if err != nil {
+		return false
+	}
+	return slices.Equal(ca, cb)
+}
+
+// Hash returns the hex encoded SHA-256 hash of the canonical N-Quads serialization of the quads in the
+// dataset, with blank nodes relabelled by Canonicalize and the quads in the order it returns them. Datasets
+// that are isomorphic have the same hash regardless of the labels of their blank nodes or the order in
+// which their quads were added, so the hash can be used to identify and compare datasets across systems.
+// ErrCanonicalizationLimit is returned if the dataset requires an excessive amount of work to canonicalize.
+func (d *Dataset) Hash() (string, error) {
+	quads, err := Canonicalize(d.Quads())
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	var buf []byte
+	for _, q := range quads {
+		buf = appendCanonicalQuad(buf[:0], q, nil)
+		h.Write(buf)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// An idIssuer issues identifiers with a common prefix, remembering the order in which they were issued.
+type idIssuer struct {
+	prefix string
+	issued map[string]string
+	order  []string // the existing identifiers in the order they were issued
+}
+
+func newIDIssuer(prefix string) *idIssuer {
+	return &idIssuer{prefix: prefix, issued: make(map[string]string)}
+}
+
+// issue returns the identifier issued for existing, issuing a new one if necessary.
+func (i *idIssuer) issue(existing string) string {
+	if id, ok := i.issued[existing]; ok {
+		return id
+	}
+	id := i.prefix + strconv.Itoa(len(i.order))
+	i.issued[existing] = id
+	i.order = append(i.order, existing)
+	return id
+}
+
+func (i *idIssuer) has(existing string) bool {
+	_, ok := i.issued[existing]
+	return ok
+}
+
+func (i *idIssuer) copy() *idIssuer {
+	c := &idIssuer{
+		prefix: i.prefix,
+		issued: make(map[string]string, len(i.issued)),
+		order:  append([]string(nil), i.order...),
+	}
+	for k, v := range i.issued {
+		c.issued[k] = v
+	}
+	return c
+}
+
+// canonicalizer holds the state of the canonicalization algorithm.
+type canonicalizer struct {
+	quads        []Quad
+	blankQuads   map[string][]Quad // the quads that mention each blank node
+	canonical    *idIssuer
+	firstDegree  map[string]string // cache of first degree hashes
+	nDegreeCalls int
+}
+
+func newCanonicalizer(quads []Quad) *canonicalizer {
+	c := &canonicalizer{
+		quads:       quads,
+		blankQuads:  make(map[string][]Quad),
+		canonical:   newIDIssuer("c14n"),
+		firstDegree: make(map[string]string),
+	}
+	labels := make(map[string]bool)
+	for _, q := range quads {
+		clear(labels)
+		collectBlankLabels(q, labels)
+		for label := range labels {
+			c.blankQuads[label] = append(c.blankQuads[label], q)
+		}
+	}
+	return c
+}
+
+func (c *canonicalizer) run() error {
+	hashToBlanks := make(map[string][]string)
+	for id := range c.blankQuads {
+		h := c.hashFirstDegree(id)
+		hashToBlanks[h] = append(hashToBlanks[h], id)
+	}
+
+	hashes := make([]string, 0, len(hashToBlanks))
+	for h := range hashToBlanks {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+
+	// Blank nodes with a unique first degree hash are issued canonical identifiers first
+	var shared []string
+	for _, h := range hashes {
+		ids := hashToBlanks[h]
+		if len(ids) > 1 {
+			shared = append(shared, h)
+			continue
+		}
+		c.canonical.issue(ids[0])
+	}
+
+	for _, h := range shared {
+		type pathResult struct {
+			hash   string
+			issuer *idIssuer
+		}
+		var results []pathResult
+		for _, id := range hashToBlanks[h] {
+			if c.canonical.has(id) {
+				continue
+			}
+			temp := newIDIssuer("b")
+			temp.issue(id)
+			hash, issuer, err := c.hashNDegree(id, temp)
+			if err != nil {
+				return err
+			}
+			results = append(results, pathResult{hash: hash, issuer: issuer})
+		}
+		sort.SliceStable(results, func(i, j int) bool { return results[i].hash < results[j].hash })
+		for _, r := range results {
+			for _, existing := range r.issuer.order {
+				c.canonical.issue(existing)
+			}
+		}
+	}
+	return nil
+}
+
+// relabel returns t with the labels of its blank nodes, including those within quoted triples, replaced by
+// their canonical identifiers.
+func (c *canonicalizer) relabel(t rdf.Term) rdf.Term {
+	return relabelBlankNodes(t, c.canonical.issue)
+}
+
+// hashFirstDegree implements the Hash First Degree Quads algorithm.
+func (c *canonicalizer) hashFirstDegree(id string) string {
+	if h, ok := c.firstDegree[id]; ok {
+		return h
+	}
+
+	label := func(l string) string {
+		if l == id {
+			return "a"
+		}
+		return "z"
+	}
+	lines := make([]string, 0, len(c.blankQuads[id]))
+	for _, q := range c.blankQuads[id] {
+		lines = append(lines, string(appendCanonicalQuad(nil, q, label)))
+	}
+	sort.Strings(lines)
+
+	h := sha256.New()
+	for _, l := range lines {
+		h.Write([]byte(l))
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	c.firstDegree[id] = sum
+	return sum
+}
+
+// hashRelated implements the Hash Related Blank Node algorithm.
+func (c *canonicalizer) hashRelated(related string, q Quad, issuer *idIssuer, position string) string {
+	input := position
+	if position != "g" {
+		input += "<" + q.P.Value + ">"
+	}
+	if id, ok := c.canonical.issued[related]; ok {
+		input += "_:" + id
+	} else if id, ok := issuer.issued[related]; ok {
+		input += "_:" + id
+	} else {
+		input += c.hashFirstDegree(related)
+	}
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashNDegree implements the Hash N-Degree Quads algorithm.
+func (c *canonicalizer) hashNDegree(id string, issuer *idIssuer) (string, *idIssuer, error) {
+	c.nDegreeCalls++
+	if c.nDegreeCalls > maxNDegreeCalls {
+		return "", nil, ErrCanonicalizationLimit
+	}
+
+	// Blank nodes within a quoted triple are related at the position of the quoted triple
+	relatedByHash := make(map[string][]string)
+	for _, q := range c.blankQuads[id] {
+		for _, comp := range [...]struct {
+			term     rdf.Term
+			position string
+		}{{q.S, "s"}, {q.O, "o"}, {q.G, "g"}} {
+			relabelBlankNodes(comp.term, func(related string) string {
+				if related != id {
+					h := c.hashRelated(related, q, issuer, comp.position)
+					relatedByHash[h] = append(relatedByHash[h], related)
+				}
+				return related
+			})
+		}
+	}
+
+	hashes := make([]string, 0, len(relatedByHash))
+	for h := range relatedByHash {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+
+	data := sha256.New()
+	for _, h := range hashes {
+		data.Write([]byte(h))
+
+		chosenPath := ""
+		var chosenIssuer *idIssuer
+		var permErr error
+		permute(relatedByHash[h], func(perm []string) bool {
+			issuerCopy := issuer.copy()
+			path := ""
+			var recursion []string
+
+			for _, related := range perm {
+				if id, ok := c.canonical.issued[related]; ok {
+					path += "_:" + id
+				} else {
+					if !issuerCopy.has(related) {
+						recursion = append(recursion, related)
+					}
+					path += "_:" + issuerCopy.issue(related)
+				}
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return true
+				}
+			}
+
+			for _, related := range recursion {
+				hash, resultIssuer, err := c.hashNDegree(related, issuerCopy)
+				if err != nil {
+					permErr = err
+					return false
+				}
+				path += "_:" + issuerCopy.issue(related)
+				path += "<" + hash + ">"
+				issuerCopy = resultIssuer
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return true
+				}
+			}
+
+			if chosenPath == "" || path < chosenPath {
+				chosenPath = path
+				chosenIssuer = issuerCopy
+			}
+			return true
+		})
+		if permErr != nil {
+			return "", nil, permErr
+		}
+
+		data.Write([]byte(chosenPath))
+		issuer = chosenIssuer
+	}
+
+	return hex.EncodeToString(data.Sum(nil)), issuer, nil
+}
+
+// permute calls fn with each permutation of items until fn returns false.
+func permute(items []string, fn func([]string) bool) {
+	perm := append([]string(nil), items...)
+	var generate func(k int) bool
+	generate = func(k int) bool {
+		if k == len(perm) {
+			return fn(perm)
+		}
+		for i := k; i < len(perm); i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			if !generate(k + 1) {
+				return false
+			}
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+		return true
+	}
+	generate(0)
+}
+
+// appendCanonicalQuad appends the canonical N-Quads serialization of q to dst, terminated by a newline, as
+// used by RDFC-1.0. If label is not nil, blank node labels, including those within quoted triples, are
+// replaced by the result of calling label.
+func appendCanonicalQuad(dst []byte, q Quad, label func(string) string) []byte {
+	terms := [...]rdf.Term{q.S, q.P, q.O, q.G}
+	for i, t := range terms {
+		if t.Kind == rdf.UnknownTerm {
+			continue
+		}
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		if t.Kind == EscapedLiteralTerm {
+			if unescaped, err := UnescapeTerm(t); err == nil {
+				t = unescaped
+			}
+		}
+		switch t.Kind {
+		case rdf.BlankTerm:
+			dst = append(dst, "_:"...)
+			if label != nil {
+				dst = append(dst, label(t.Value)...)
+			} else {
+				dst = append(dst, t.Value...)
+			}
+		case rdf.LiteralTerm:
+			dst = appendCanonicalString(dst, t.Value)
+			if t.Language != "" {
+				dst = append(dst, '@')
+				dst = append(dst, t.Language...)
+			} else if t.Datatype != "" && t.Datatype != xsdString {
+				dst = append(dst, "^^"...)
+				dst = appendIRI(dst, t.Datatype)
+			}
+		case QuotedTripleTerm:
+			if label != nil {
+				t = relabelBlankNodes(t, label)
+			}
+			dst = AppendTerm(dst, t)
+		default:
+			dst = AppendTerm(dst, t)
+		}
+	}
+	return append(dst, " .\n"...)
+}
+
+// appendCanonicalString appends s to dst as a quoted literal value using the escaping rules of canonical
+// N-Quads: the characters with short escapes use them, other control characters use \u escapes and all
+// remaining characters are written directly.
+func appendCanonicalString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '"':
+			dst = append(dst, '\\', '"')
+		case '\\':
+			dst = append(dst, '\\', '\\')
+		default:
+			if c < 0x20 || c == 0x7F {
+				dst = appendCodepoint(dst, rune(c))
+				continue
+			}
+			dst = append(dst, c)
+		}
+	}
+	return append(dst, '"')
+}
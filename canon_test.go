@@ -0,0 +1,271 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func readTestQuads(t *testing.T, input string) []Quad {
+	t.Helper()
+	var quads []Quad
+	r := NewReader(strings.NewReader(input))
+	for r.Next() {
+		quads = append(quads, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	return quads
+}
+
+func canonicalText(t *testing.T, input string) string {
+	t.Helper()
+	quads, err := Canonicalize(readTestQuads(t, input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	var b []byte
+	for _, q := range quads {
+		b = AppendQuad(b, q)
+		b = append(b, '\n')
+	}
+	return string(b)
+}
+
+var canonicalizeTests = []struct {
+	name  string
+	input string
+	want  string
+}{
+	{
+		name: "no blank nodes",
+		input: `<http://example/s> <http://example/p> "b" .
+<http://example/s> <http://example/p> "a" <http://example/g> .
+<http://example/s> <http://example/p> "b" .
+`,
+		want: `<http://example/s> <http://example/p> "a" <http://example/g> .
+<http://example/s> <http://example/p> "b" .
+`,
+	},
+	{
+		name: "unique hashes",
+		input: `_:e0 <http://example.com/#p1> _:e1 .
+_:e1 <http://example.com/#p2> "Foo" .
+`,
+		want: `_:c14n0 <http://example.com/#p1> _:c14n1 .
+_:c14n1 <http://example.com/#p2> "Foo" .
+`,
+	},
+	{
+		name: "shared hashes",
+		input: `_:e0 <http://example.org/vocab#next> _:e1 .
+_:e0 <http://example.org/vocab#prev> _:e1 .
+_:e1 <http://example.org/vocab#next> _:e0 .
+_:e1 <http://example.org/vocab#prev> _:e0 .
+`,
+		want: `_:c14n0 <http://example.org/vocab#next> _:c14n1 .
+_:c14n0 <http://example.org/vocab#prev> _:c14n1 .
+_:c14n1 <http://example.org/vocab#next> _:c14n0 .
+_:c14n1 <http://example.org/vocab#prev> _:c14n0 .
+`,
+	},
+	{
+		name: "blank graph name",
+		input: `<http://example/s> <http://example/p> _:x _:g .
+_:x <http://example/p> "x" _:g .
+`,
+		want: `<http://example/s> <http://example/p> _:c14n1 _:c14n0 .
+_:c14n1 <http://example/p> "x" _:c14n0 .
+`,
+	},
+	{
+		name: "quoted triple",
+		input: `<< _:x <http://example/p> "x" >> <http://example/q> _:y .
+`,
+		want: `<< _:c14n0 <http://example/p> "x" >> <http://example/q> _:c14n1 .
+`,
+	},
+}
+
+func TestCanonicalize(t *testing.T) {
+	for _, tc := range canonicalizeTests {
+		got := canonicalText(t, tc.input)
+		if got != tc.want {
+			t.Errorf("%s: got:\n%s\nwanted:\n%s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCanonicalizeIsomorphic(t *testing.T) {
+	// Two rings of blank nodes whose nodes cannot be told apart by their first degree hashes
+	inputs := []string{
+		`_:a <http://example/p> _:b .
+_:b <http://example/p> _:c .
+_:c <http://example/p> _:a .
+_:d <http://example/p> _:e .
+_:e <http://example/p> _:f .
+_:f <http://example/p> _:g .
+_:g <http://example/p> _:d .
+_:a <http://example/q> "1" .
+`,
+		`_:n7 <http://example/p> _:n1 .
+_:n2 <http://example/q> "1" .
+_:n2 <http://example/p> _:n4 .
+_:n4 <http://example/p> _:n6 .
+_:n1 <http://example/p> _:n5 .
+_:n6 <http://example/p> _:n2 .
+_:n3 <http://example/p> _:n7 .
+_:n5 <http://example/p> _:n3 .
+`,
+	}
+
+	want := canonicalText(t, inputs[0])
+	for _, input := range inputs[1:] {
+		if got := canonicalText(t, input); got != want {
+			t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+		}
+	}
+}
+
+func TestEqualQuads(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "empty", want: true},
+		{name: "identical", a: datasetInput, b: datasetInput, want: true},
+		{
+			name: "reordered with duplicates",
+			a:    "<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" .\n",
+			b:    "<http://example/s> <http://example/p> \"b\" .\n<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" .\n",
+			want: true,
+		},
+		{
+			name: "relabelled",
+			a:    "_:a <http://example/p> _:b _:g .\n_:b <http://example/p> \"x\" .\n",
+			b:    "_:y <http://example/p> _:z _:x .\n_:z <http://example/p> \"x\" .\n",
+			want: true,
+		},
+		{
+			name: "inconsistently relabelled",
+			a:    "_:a <http://example/p> _:b .\n_:b <http://example/p> \"x\" .\n",
+			b:    "_:a <http://example/p> _:b .\n_:a <http://example/p> \"x\" .\n",
+			want: false,
+		},
+		{
+			name: "merged blank nodes",
+			a:    "_:a <http://example/p> \"x\" .\n_:b <http://example/p> \"x\" .\n",
+			b:    "_:a <http://example/p> \"x\" .\n",
+			want: false,
+		},
+		{
+			name: "blank node and iri",
+			a:    "_:a <http://example/p> \"x\" .\n",
+			b:    "<http://example/a> <http://example/p> \"x\" .\n",
+			want: false,
+		},
+		{name: "isomorphic rings", a: canonicalizeTests[2].input, b: canonicalizeTests[2].want, want: true},
+		{
+			name: "quoted triples",
+			a:    "<< _:a <http://example/p> \"x\" >> <http://example/q> _:a .\n<http://example/s> <http://example/p> << _:c <http://example/p> _:a >> .\n",
+			b:    "<< _:b <http://example/p> \"x\" >> <http://example/q> _:b .\n<http://example/s> <http://example/p> << _:d <http://example/p> _:b >> .\n",
+			want: true,
+		},
+		{
+			name: "quoted triples only",
+			a:    "<< _:a <http://example/p> \"x\" >> <http://example/q> \"y\" .\n",
+			b:    "<< _:b <http://example/p> \"x\" >> <http://example/q> \"y\" .\n",
+			want: true,
+		},
+		{
+			name: "quoted triples with different structure",
+			a:    "<< _:a <http://example/p> \"x\" >> <http://example/q> _:a .\n",
+			b:    "<< _:a <http://example/p> \"x\" >> <http://example/q> _:b .\n",
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EqualQuads(readTestQuads(t, tc.a), readTestQuads(t, tc.b)); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEqualQuadsDuplicates(t *testing.T) {
+	a := readTestQuads(t, `_:a <http://example/p> _:b .
+_:b <http://example/q> "x" .
+_:b <http://example/p> _:c .
+_:c <http://example/q> "y" .
+`)
+	b := readTestQuads(t, `_:n1 <http://example/p> _:n2 .
+_:n2 <http://example/q> "x" .
+_:n2 <http://example/p> _:n3 .
+_:n3 <http://example/q> "y" .
+_:n2 <http://example/q> "x" .
+`)
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range 50 {
+		rng.Shuffle(len(b), func(i, j int) { b[i], b[j] = b[j], b[i] })
+		if !EqualQuads(a, b) {
+			t.Fatalf("shuffle %d: got not equal for %v", i, b)
+		}
+	}
+}
+
+func TestDatasetHash(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  string // the canonical N-Quads hashed
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "no blank nodes", input: canonicalizeTests[0].input, want: canonicalizeTests[0].want},
+		{name: "blank nodes", input: canonicalizeTests[3].input, want: canonicalizeTests[3].want},
+		{
+			name:  "relabelled and reordered",
+			input: "_:x <http://example/p> \"x\" _:y .\n<http://example/s> <http://example/p> _:x _:y .\n",
+			want:  canonicalizeTests[3].want,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := loadTestDataset(t, tc.input).Hash()
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			sum := sha256.Sum256([]byte(tc.want))
+			if want := hex.EncodeToString(sum[:]); got != want {
+				t.Errorf("got %s, wanted %s", got, want)
+			}
+		})
+	}
+
+	a, _ := loadTestDataset(t, canonicalizeTests[1].input).Hash()
+	b, _ := loadTestDataset(t, canonicalizeTests[2].input).Hash()
+	if a == b {
+		t.Errorf("got the same hash for different datasets")
+	}
+}
+
+func TestAppendCanonicalQuad(t *testing.T) {
+	q := readTestQuads(t, `_:b <http://example/p> "a\tb\u0001\"c"^^<http://www.w3.org/2001/XMLSchema#string> .`)[0]
+	got := string(appendCanonicalQuad(nil, q, func(string) string { return "z" }))
+	want := "_:z <http://example/p> \"a\\tb\\u0001\\\"c\" .\n"
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// A runeSet is a bitmap holding a set of characters of the basic multilingual plane.
+type runeSet [0x10000 / 64]uint64
+
+// newRuneSet returns the set of characters of the basic multilingual plane for which in returns true.
+func newRuneSet(in func(rune) bool) *runeSet {
+	var s runeSet
+	for r := rune(0); r < 0x10000; r++ {
+		if in(r) {
+			s[r>>6] |= 1 << (r & 63)
+		}
+	}
+	return &s
+}
+
+// has reports whether r, which must lie in the basic multilingual plane, is in s.
+func (s *runeSet) has(r rune) bool {
+	return s[r>>6]&(1<<(r&63)) != 0
+}
+
+// Lookup tables for the character classes tested while reading blank node labels, which replace a chain of
+// range comparisons with a single test for each character.
+var (
+	pnCharsUSet = newRuneSet(pnCharsU)
+	pnCharsSet  = newRuneSet(pnChars)
+)
+
+// isPnCharsU reports whether r is in the PN_CHARS_U class of the grammar.
+func isPnCharsU(r rune) bool {
+	if r >= 0 && r < 0x10000 {
+		return pnCharsUSet.has(r)
+	}
+	return r >= 0x10000 && r <= 0xEFFFF
+}
+
+// isPnChars reports whether r is in the PN_CHARS class of the grammar.
+func isPnChars(r rune) bool {
+	if r >= 0 && r < 0x10000 {
+		return pnCharsSet.has(r)
+	}
+	return r >= 0x10000 && r <= 0xEFFFF
+}
@@ -0,0 +1,30 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCharacterClasses(t *testing.T) {
+	testCases := []struct {
+		name  string
+		table func(rune) bool
+		ref   func(rune) bool
+	}{
+		{name: "PN_CHARS_U", table: isPnCharsU, ref: pnCharsU},
+		{name: "PN_CHARS", table: isPnChars, ref: pnChars},
+	}
+
+	for _, tc := range testCases {
+		for r := rune(-1); r <= utf8.MaxRune+1; r++ {
+			if got, want := tc.table(r), tc.ref(r); got != want {
+				t.Errorf("%s: got %v for %U, wanted %v", tc.name, got, r, want)
+			}
+		}
+	}
+}
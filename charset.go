@@ -0,0 +1,83 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// A Charset identifies the character encoding of the input to a Reader.
+type Charset int
+
+const (
+	UTF8        Charset = iota // UTF-8, the encoding required by the N-Quads specification
+	Latin1                     // ISO-8859-1
+	Windows1252                // Windows-1252, a superset of the printable characters of ISO-8859-1
+)
+
+// windows1252 maps the bytes 0x80 to 0x9F in Windows-1252 to the characters they encode. The five
+// undefined bytes are mapped to the corresponding C1 control characters, as they are in ISO-8859-1.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// WithCharset configures the Reader to transcode its input from the legacy single-byte encoding cs to UTF-8
+// before parsing. This allows archived dumps that were not written in UTF-8 to be read without producing
+// mojibake literals.
+func WithCharset(cs Charset) Option {
+	return func(r *Reader) {
+		r.charset = cs
+	}
+}
+
+// charsetReader is an io.Reader that transcodes bytes read from a single-byte encoded reader to UTF-8.
+type charsetReader struct {
+	r       io.Reader
+	charset Charset
+	in      []byte
+	out     []byte
+	pending []byte // transcoded bytes not yet returned by Read
+	err     error  // error to return once pending is exhausted
+}
+
+func newCharsetReader(r io.Reader, cs Charset) *charsetReader {
+	return &charsetReader{
+		r:       r,
+		charset: cs,
+		in:      make([]byte, 4096),
+	}
+}
+
+func (c *charsetReader) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		n, err := c.r.Read(c.in)
+		c.out = c.out[:0]
+		for _, b := range c.in[:n] {
+			c.out = utf8.AppendRune(c.out, c.decode(b))
+		}
+		c.pending = c.out
+		c.err = err
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// decode returns the character encoded by b.
+func (c *charsetReader) decode(b byte) rune {
+	if c.charset == Windows1252 && b >= 0x80 && b <= 0x9F {
+		return windows1252[b-0x80]
+	}
+	return rune(b)
+}
@@ -0,0 +1,56 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWithCharset(t *testing.T) {
+	testCases := []struct {
+		name    string
+		charset Charset
+		input   []byte
+		want    string
+	}{
+		{
+			name:    "latin1",
+			charset: Latin1,
+			input:   []byte("caf\xe9 \xab\xa3\xbb"),
+			want:    "café «£»",
+		},
+		{
+			name:    "windows1252",
+			charset: Windows1252,
+			input:   []byte("\x93caf\xe9\x94 \x80\x99\x81"),
+			want:    "“café” €™\u0081",
+		},
+		{
+			name:    "latin1-c1",
+			charset: Latin1,
+			input:   []byte("\x80"),
+			want:    "\u0080",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := append([]byte(`<http://example/s> <http://example/p> "`), tc.input...)
+			input = append(input, "\" .\n"...)
+
+			// Read a byte at a time to exercise buffering
+			r := NewReader(iotest.OneByteReader(bytes.NewReader(input)), WithCharset(tc.charset))
+			if !r.Next() {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if got := r.Quad().O.Value; got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"sort"
+
+	"github.com/iand/gordf"
+)
+
+// A TypeFilter selects the quads whose subject is an instance of one of a set of classes, as determined by
+// rdf:type statements. Since the type of a subject may be stated anywhere in the input, filtering takes two
+// passes: Collect reads the input once to find the matching subjects, after which Match or Filter can be
+// used with a second Reader over the same input.
+type TypeFilter struct {
+	Classes  []rdf.Term
+	subjects map[rdf.Term]struct{}
+}
+
+// NewTypeFilter returns a new TypeFilter that selects instances of any of the given classes.
+func NewTypeFilter(classes ...rdf.Term) *TypeFilter {
+	return &TypeFilter{Classes: classes}
+}
+
+// Collect reads all quads from r, recording the subjects that have an rdf:type of one of the filter's classes.
+func (f *TypeFilter) Collect(r *Reader) error {
+	for r.Next() {
+		f.Add(r.Quad())
+	}
+	return r.Err()
+}
+
+// Add records the subject of q if q states that it has an rdf:type of one of the filter's classes.
+func (f *TypeFilter) Add(q Quad) {
+	if !isTypeStatement(q) {
+		return
+	}
+	for _, class := range f.Classes {
+		if q.O == class {
+			if f.subjects == nil {
+				f.subjects = make(map[rdf.Term]struct{})
+			}
+			f.subjects[q.S] = struct{}{}
+			return
+		}
+	}
+}
+
+// Match reports whether the subject of q has been recorded as an instance of one of the filter's classes.
+func (f *TypeFilter) Match(q Quad) bool {
+	_, ok := f.subjects[q.S]
+	return ok
+}
+
+// Filter reads all quads from r, calling fn for each one that matches the filter. Iteration stops at the
+// first error returned by fn.
+func (f *TypeFilter) Filter(r *Reader, fn func(Quad) error) error {
+	for r.Next() {
+		if !f.Match(r.Quad()) {
+			continue
+		}
+		if err := fn(r.Quad()); err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
+
+// A ClassCount is the number of rdf:type statements that have a class as their object.
+type ClassCount struct {
+	Class rdf.Term
+	Count int
+}
+
+// ClassHistogram reads all quads from r and returns the number of rdf:type statements for each class, ordered
+// by descending count. Classes with equal counts are ordered by their N-Quads serialization.
+func ClassHistogram(r *Reader) ([]ClassCount, error) {
+	counts := make(map[rdf.Term]int)
+	for r.Next() {
+		if q := r.Quad(); isTypeStatement(q) {
+			counts[q.O]++
+		}
+	}
+	if r.Err() != nil {
+		return nil, r.Err()
+	}
+
+	hist := make([]ClassCount, 0, len(counts))
+	for class, n := range counts {
+		hist = append(hist, ClassCount{Class: class, Count: n})
+	}
+	sort.Slice(hist, func(i, j int) bool {
+		if hist[i].Count != hist[j].Count {
+			return hist[i].Count > hist[j].Count
+		}
+		return termText(hist[i].Class) < termText(hist[j].Class)
+	})
+	return hist, nil
+}
+
+// isTypeStatement reports whether the predicate of q is rdf:type.
+func isTypeStatement(q Quad) bool {
+	return q.P.Kind == rdf.IRITerm && q.P.Value == rdfType
+}
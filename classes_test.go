@@ -0,0 +1,64 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+const typedInput = `<http://example/alice> <http://example/name> "Alice" .
+<http://example/rex> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Dog> .
+<http://example/rex> <http://example/name> "Rex" .
+<http://example/bob> <http://example/name> "Bob" .
+<http://example/alice> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> .
+<http://example/bob> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> <http://example/g> .
+<http://example/bob> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Agent> .
+`
+
+func TestTypeFilter(t *testing.T) {
+	f := NewTypeFilter(rdf.IRI("http://example/Person"))
+	if err := f.Collect(NewReader(strings.NewReader(typedInput))); err != nil {
+		t.Fatalf("collect: got unexpected error %q", err)
+	}
+
+	var subjects []string
+	err := f.Filter(NewReader(strings.NewReader(typedInput)), func(q Quad) error {
+		subjects = append(subjects, q.S.Value)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("filter: got unexpected error %q", err)
+	}
+
+	want := "http://example/alice http://example/bob http://example/alice http://example/bob http://example/bob"
+	if got := strings.Join(subjects, " "); got != want {
+		t.Errorf("got subjects %s, wanted %s", got, want)
+	}
+}
+
+func TestClassHistogram(t *testing.T) {
+	hist, err := ClassHistogram(NewReader(strings.NewReader(typedInput)))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	want := []ClassCount{
+		{Class: rdf.IRI("http://example/Person"), Count: 2},
+		{Class: rdf.IRI("http://example/Agent"), Count: 1},
+		{Class: rdf.IRI("http://example/Dog"), Count: 1},
+	}
+	if len(hist) != len(want) {
+		t.Fatalf("got %d classes, wanted %d", len(hist), len(want))
+	}
+	for i := range want {
+		if hist[i] != want[i] {
+			t.Errorf("class %d: got %v, wanted %v", i, hist[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// WithCommentHandler configures the Reader to call fn for each comment it reads, whether on a line of its
+// own or following a statement. The line is the line number on which the comment appears and text is the
+// comment following the '#', without the line terminator. Comments are reported in the order they appear
+// in the input. A comment following a statement on the same line is reported before Next returns the quad.
+func WithCommentHandler(fn func(line int, text string)) Option {
+	return func(r *Reader) {
+		r.onComment = fn
+	}
+}
+
+// readComment reads the remainder of a comment after the '#', passing its text to r.onComment once the end
+// of the line or input is reached.
+func (r *Reader) readComment() (r1 rune, err error) {
+	line := r.line
+	r.comment = r.comment[:0]
+	for {
+		r1, err = r.readRune()
+		if err != nil || isEOL(r1) {
+			break
+		}
+		r.comment = utf8.AppendRune(r.comment, r1)
+	}
+	if err == nil || err == io.EOF {
+		r.onComment(line, string(r.comment))
+	}
+	return r1, err
+}
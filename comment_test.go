@@ -0,0 +1,75 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCommentHandler(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "none",
+			input: "<http://example/s> <http://example/p> \"1\" .\n",
+			want:  []string{"1: quad"},
+		},
+		{
+			name: "own line",
+			input: "# Licensed under CC0\n" +
+				"#\n" +
+				"<http://example/s> <http://example/p> \"1\" .\n",
+			want: []string{"1: Licensed under CC0", "2:", "3: quad"},
+		},
+		{
+			name: "after statement",
+			input: "<http://example/s> <http://example/p> \"1\" . # source: dump.nq\r\n" +
+				"<http://example/s> <http://example/p> \"2\" .#no space\n",
+			want: []string{"1: source: dump.nq", "1: quad", "2:no space", "2: quad"},
+		},
+		{
+			name: "indented after blank lines",
+			input: "\n\n  # ünïcode\n" +
+				"<http://example/s> <http://example/p> \"1\" .\n",
+			want: []string{"3: ünïcode", "4: quad"},
+		},
+		{
+			name: "end of input",
+			input: "<http://example/s> <http://example/p> \"1\" .\n" +
+				"# last",
+			want: []string{"1: quad", "2: last"},
+		},
+		{
+			name:  "end of input after statement",
+			input: "<http://example/s> <http://example/p> \"1\" . # last",
+			want:  []string{"1: last", "1: quad"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			r := NewReader(strings.NewReader(tc.input), WithCommentHandler(func(line int, text string) {
+				got = append(got, fmt.Sprintf("%d:%s", line, text))
+			}))
+			for r.Next() {
+				line, _, _ := r.Position()
+				got = append(got, fmt.Sprintf("%d: quad", line))
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
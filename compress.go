@@ -0,0 +1,77 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Compression selects how the output of a WriteCloser is compressed.
+type Compression int
+
+const (
+	// Uncompressed writes output without compression.
+	Uncompressed Compression = iota
+
+	// Gzip compresses output using gzip.
+	Gzip
+)
+
+// CompressionFor returns the compression conventionally indicated by the extension of the file name, Gzip
+// for names ending in .gz and Uncompressed otherwise.
+func CompressionFor(name string) Compression {
+	if strings.EqualFold(filepath.Ext(name), ".gz") {
+		return Gzip
+	}
+	return Uncompressed
+}
+
+// A WriteCloser is a Writer that owns its destination, such as a compressor or a file, which must be closed
+// once all quads have been written.
+type WriteCloser struct {
+	*Writer
+	closers []io.Closer // closed in order by Close
+}
+
+// NewCompressedWriter returns a new WriteCloser that writes to w using compression c. Closing the WriteCloser
+// completes the compressed stream but does not close w.
+func NewCompressedWriter(w io.Writer, c Compression) *WriteCloser {
+	if c == Gzip {
+		zw := gzip.NewWriter(w)
+		return &WriteCloser{Writer: NewWriter(zw), closers: []io.Closer{zw}}
+	}
+	return &WriteCloser{Writer: NewWriter(w)}
+}
+
+// CreateFile creates or truncates the named file and returns a WriteCloser that writes to it, compressed
+// according to the file's extension as reported by CompressionFor.
+func CreateFile(name string) (*WriteCloser, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	wc := NewCompressedWriter(f, CompressionFor(name))
+	wc.closers = append(wc.closers, f)
+	return wc, nil
+}
+
+// Close flushes any buffered quads, completes any compressed stream and closes any file opened by CreateFile.
+// It returns the first error encountered.
+func (wc *WriteCloser) Close() error {
+	wc.Flush()
+	err := wc.Error()
+	for _, c := range wc.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	wc.closers = nil
+	return err
+}
@@ -0,0 +1,94 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompressionFor(t *testing.T) {
+	testCases := []struct {
+		name string
+		want Compression
+	}{
+		{name: "dump.nq", want: Uncompressed},
+		{name: "dump.nq.gz", want: Gzip},
+		{name: "DUMP.NQ.GZ", want: Gzip},
+		{name: "dump", want: Uncompressed},
+	}
+
+	for _, tc := range testCases {
+		if got := CompressionFor(tc.name); got != tc.want {
+			t.Errorf("%s: got %v, wanted %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNewCompressedWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCompressedWriter(&buf, Gzip)
+	if err := w.Write(messageCases[0].quad); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if want := messageCases[0].encoded + "\n"; string(got) != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestCreateFile(t *testing.T) {
+	dir := t.TempDir()
+	want := messageCases[1].encoded + "\n"
+
+	for _, name := range []string{"out.nq", "out.nq.gz"} {
+		path := filepath.Join(dir, name)
+		w, err := CreateFile(path)
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		if err := w.Write(messageCases[1].quad); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		var r io.Reader = f
+		if CompressionFor(name) == Gzip {
+			if r, err = gzip.NewReader(f); err != nil {
+				t.Fatalf("%s: got unexpected error %q", name, err)
+			}
+		}
+		got, err := io.ReadAll(r)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: got unexpected error %q", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s: got %q, wanted %q", name, got, want)
+		}
+	}
+}
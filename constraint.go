@@ -0,0 +1,161 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iand/gordf"
+)
+
+// A Shape describes the constraints that apply to every subject that has Class as an rdf:type.
+// It supports a small subset of SHACL suitable for checking in a single streaming pass.
+type Shape struct {
+	Class      rdf.Term             // the class whose instances are checked
+	Properties []PropertyConstraint // the constraints on the properties of each instance
+}
+
+// A PropertyConstraint constrains the values of a single predicate.
+type PropertyConstraint struct {
+	Predicate rdf.Term // the predicate being constrained
+	MinCount  int      // the minimum number of values required, a MinCount of 1 makes the predicate required
+	MaxCount  int      // the maximum number of values allowed, unbounded if zero
+	Datatypes []string // the datatype IRIs allowed for each value, any value is allowed if empty
+}
+
+// A Violation describes a subject that fails to meet a PropertyConstraint.
+type Violation struct {
+	Subject   rdf.Term // the subject that failed the constraint
+	Class     rdf.Term // the class of the Shape containing the constraint
+	Predicate rdf.Term // the constrained predicate
+	Component string   // the IRI of the SHACL constraint component that failed, such as sh:MinCountConstraintComponent
+	Message   string   // a human readable description of the violation
+}
+
+// Quads returns the violation as quads in the default graph that describe a SHACL validation result
+// identified by node.
+func (v Violation) Quads(node rdf.Term) []Quad {
+	return []Quad{
+		{S: node, P: rdf.IRI(rdfType), O: rdf.IRI(shNS + "ValidationResult")},
+		{S: node, P: rdf.IRI(shNS + "resultSeverity"), O: rdf.IRI(shNS + "Violation")},
+		{S: node, P: rdf.IRI(shNS + "focusNode"), O: v.Subject},
+		{S: node, P: rdf.IRI(shNS + "resultPath"), O: v.Predicate},
+		{S: node, P: rdf.IRI(shNS + "sourceConstraintComponent"), O: rdf.IRI(v.Component)},
+		{S: node, P: rdf.IRI(shNS + "resultMessage"), O: rdf.Literal(v.Message)},
+	}
+}
+
+// A Checker checks the subjects read from a stream of quads against a set of shapes.
+type Checker struct {
+	Shapes []Shape
+}
+
+// Check reads quads from r and calls fn for each violation of the checker's shapes. The input must be
+// grouped by subject, so that all the quads for a subject are adjacent, since each group is checked as soon
+// as it is complete. Iteration stops at the first error returned by fn.
+func (c *Checker) Check(r *Reader, fn func(Violation) error) error {
+	return groupBySubject(r, false, func(s rdf.Term, quads []Quad) error {
+		for _, shape := range c.Shapes {
+			if !hasType(quads, shape.Class) {
+				continue
+			}
+			for _, pc := range shape.Properties {
+				for _, v := range pc.check(s, quads) {
+					v.Class = shape.Class
+					if err := fn(v); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Report reads quads from r and writes a report of each violation of the checker's shapes to w, using a
+// blank node to identify each validation result. It returns the number of violations found.
+func (c *Checker) Report(r *Reader, w *Writer) (int, error) {
+	n := 0
+	err := c.Check(r, func(v Violation) error {
+		n++
+		for _, q := range v.Quads(rdf.Blank("v" + strconv.Itoa(n))) {
+			if err := w.Write(q); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+// check returns the violations of the constraint by the quads describing subject s.
+func (pc *PropertyConstraint) check(s rdf.Term, quads []Quad) []Violation {
+	var violations []Violation
+	count := 0
+	for _, q := range quads {
+		if q.P != pc.Predicate {
+			continue
+		}
+		count++
+		if len(pc.Datatypes) > 0 && !pc.allowsDatatype(q.O) {
+			violations = append(violations, Violation{
+				Subject:   s,
+				Predicate: pc.Predicate,
+				Component: shNS + "DatatypeConstraintComponent",
+				Message:   fmt.Sprintf("value %s does not have an allowed datatype", termText(q.O)),
+			})
+		}
+	}
+
+	if count < pc.MinCount {
+		violations = append(violations, Violation{
+			Subject:   s,
+			Predicate: pc.Predicate,
+			Component: shNS + "MinCountConstraintComponent",
+			Message:   fmt.Sprintf("has %d values, fewer than the minimum of %d", count, pc.MinCount),
+		})
+	}
+	if pc.MaxCount > 0 && count > pc.MaxCount {
+		violations = append(violations, Violation{
+			Subject:   s,
+			Predicate: pc.Predicate,
+			Component: shNS + "MaxCountConstraintComponent",
+			Message:   fmt.Sprintf("has %d values, more than the maximum of %d", count, pc.MaxCount),
+		})
+	}
+	return violations
+}
+
+// allowsDatatype reports whether o is a literal with one of the constraint's datatypes. Simple literals
+// have the datatype xsd:string and language tagged literals have the datatype rdf:langString.
+func (pc *PropertyConstraint) allowsDatatype(o rdf.Term) bool {
+	if !isLiteral(o) {
+		return false
+	}
+	dt := o.Datatype
+	if o.Language != "" {
+		dt = rdfLangString
+	} else if dt == "" {
+		dt = xsdString
+	}
+	for _, allowed := range pc.Datatypes {
+		if dt == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// hasType reports whether quads contain an rdf:type statement with class as the object.
+func hasType(quads []Quad, class rdf.Term) bool {
+	for _, q := range quads {
+		if isTypeStatement(q) && q.O == class {
+			return true
+		}
+	}
+	return false
+}
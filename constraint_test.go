@@ -0,0 +1,101 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+var personShape = Shape{
+	Class: rdf.IRI("http://example/Person"),
+	Properties: []PropertyConstraint{
+		{
+			Predicate: rdf.IRI("http://example/name"),
+			MinCount:  1,
+			MaxCount:  1,
+			Datatypes: []string{"http://www.w3.org/2001/XMLSchema#string"},
+		},
+		{
+			Predicate: rdf.IRI("http://example/age"),
+			MaxCount:  1,
+			Datatypes: []string{"http://www.w3.org/2001/XMLSchema#integer"},
+		},
+	},
+}
+
+func TestCheckerCheck(t *testing.T) {
+	input := `<http://example/alice> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> .
+<http://example/alice> <http://example/name> "Alice" .
+<http://example/alice> <http://example/age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example/bob> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> .
+<http://example/bob> <http://example/age> "old" .
+<http://example/carol> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> .
+<http://example/carol> <http://example/name> "Carol" .
+<http://example/carol> <http://example/name> "Caz"@en .
+<http://example/dog> <http://example/name> "Rex" .
+<http://example/dog> <http://example/name> "Rover" .
+`
+	type result struct {
+		subject   string
+		component string
+	}
+	want := []result{
+		{"http://example/bob", "http://www.w3.org/ns/shacl#MinCountConstraintComponent"},
+		{"http://example/bob", "http://www.w3.org/ns/shacl#DatatypeConstraintComponent"},
+		{"http://example/carol", "http://www.w3.org/ns/shacl#DatatypeConstraintComponent"},
+		{"http://example/carol", "http://www.w3.org/ns/shacl#MaxCountConstraintComponent"},
+	}
+
+	c := &Checker{Shapes: []Shape{personShape}}
+	var got []result
+	err := c.Check(NewReader(strings.NewReader(input)), func(v Violation) error {
+		got = append(got, result{v.Subject.Value, v.Component})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d violations %v, wanted %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("violation %d: got %v, wanted %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCheckerReport(t *testing.T) {
+	input := `<http://example/bob> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Person> .
+`
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	c := &Checker{Shapes: []Shape{personShape}}
+	n, err := c.Report(NewReader(strings.NewReader(input)), w)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	w.Flush()
+	if n != 1 {
+		t.Errorf("got %d violations, wanted 1", n)
+	}
+
+	want := `_:v1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/ns/shacl#ValidationResult> .
+_:v1 <http://www.w3.org/ns/shacl#resultSeverity> <http://www.w3.org/ns/shacl#Violation> .
+_:v1 <http://www.w3.org/ns/shacl#focusNode> <http://example/bob> .
+_:v1 <http://www.w3.org/ns/shacl#resultPath> <http://example/name> .
+_:v1 <http://www.w3.org/ns/shacl#sourceConstraintComponent> <http://www.w3.org/ns/shacl#MinCountConstraintComponent> .
+_:v1 <http://www.w3.org/ns/shacl#resultMessage> "has 0 values, fewer than the minimum of 1" .
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got report:\n%s\nwanted:\n%s", got, want)
+	}
+}
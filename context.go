@@ -0,0 +1,34 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"context"
+	"io"
+)
+
+// WithContext configures the Reader to stop reading once ctx is cancelled or its deadline passes, after which
+// Err returns ctx.Err(). The context is checked before each statement and before each read from the
+// underlying reader, and also ends any wait for more data configured by WithFollow. A read that is already
+// blocked in the underlying reader is not interrupted.
+func WithContext(ctx context.Context) Option {
+	return func(r *Reader) {
+		r.ctx = ctx
+	}
+}
+
+// contextReader is an io.Reader that fails with the error of a context once it is done.
+type contextReader struct {
+	r   io.Reader
+	ctx context.Context
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
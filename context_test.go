@@ -0,0 +1,67 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithContext(t *testing.T) {
+	input := strings.Repeat("<http://example/s> <http://example/p> <http://example/o> .\n", 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewReader(strings.NewReader(input), WithContext(ctx))
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	cancel()
+	if r.Next() {
+		t.Fatalf("got quad after cancellation")
+	}
+	if !errors.Is(r.Err(), context.Canceled) {
+		t.Errorf("got error %v, wanted %v", r.Err(), context.Canceled)
+	}
+}
+
+func TestWithContextUnderlyingReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A statement that spans many reads stops at the next read once the context is done
+	src := io.MultiReader(strings.NewReader(`<http://example/s> <http://example/p> "`), endlessReader('a'))
+	r := NewReader(src, WithContext(ctx))
+	r.ctx = nil // skip the check made before each statement
+	if r.Next() {
+		t.Fatalf("got quad, wanted error")
+	}
+	if !errors.Is(r.Err(), context.Canceled) {
+		t.Errorf("got error %v, wanted %v", r.Err(), context.Canceled)
+	}
+}
+
+func TestWithContextFollow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	g := &growingReader{}
+	g.WriteString("<http://example/s> <http://example/p> <http://example/o> .\n")
+
+	r := NewReader(g, WithFollow(time.Millisecond), WithContext(ctx))
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if r.Next() {
+		t.Fatalf("got quad, wanted error")
+	}
+	if !errors.Is(r.Err(), context.DeadlineExceeded) {
+		t.Errorf("got error %v, wanted %v", r.Err(), context.DeadlineExceeded)
+	}
+}
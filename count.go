@@ -0,0 +1,44 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"math"
+)
+
+// Count reads all the statements in src and returns the number read. The statements are checked for syntax
+// errors without constructing their terms, as described by Skip, which makes counting much cheaper than
+// reading the quads. If an error is encountered it is returned together with the number of statements
+// read before it.
+func Count(src io.Reader) (int64, error) {
+	n, err := NewReader(src).skipStatements(math.MaxInt)
+	if err == io.EOF {
+		err = nil
+	}
+	return int64(n), err
+}
+
+// CountParallel is like Count but checks the statements on the given number of goroutines, in the manner of
+// a ParallelReader. If workers is not positive the value of runtime.GOMAXPROCS is used.
+func CountParallel(src io.Reader, workers int) (int64, error) {
+	p := NewParallelReader(src, workers)
+	p.count = true
+	p.start()
+	defer p.Close()
+
+	var n int64
+	for {
+		b := p.receive()
+		if b == nil {
+			return n, nil
+		}
+		n += int64(b.n)
+		if b.err != nil {
+			return n, b.err
+		}
+	}
+}
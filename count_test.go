@@ -0,0 +1,44 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  int64
+		err   bool
+	}{
+		{name: "empty", input: "", want: 0},
+		{name: "comments", input: "# comment\n\n# another\n", want: 0},
+		{name: "dataset", input: datasetInput, want: 8},
+		{name: "large", input: parallelInput(3000), want: 3000},
+		{name: "error", input: parallelInput(1200) + "<http://example/s> bad\n" + parallelInput(10), want: 1200, err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Count(strings.NewReader(tc.input))
+			if got != tc.want || (err != nil) != tc.err {
+				t.Errorf("Count: got %d and error %v, wanted %d", got, err, tc.want)
+			}
+			got, err = CountParallel(strings.NewReader(tc.input), 3)
+			if got != tc.want || (err != nil) != tc.err {
+				t.Errorf("CountParallel: got %d and error %v, wanted %d", got, err, tc.want)
+			}
+			var perr *ParseError
+			if tc.err && !errors.As(err, &perr) {
+				t.Errorf("CountParallel: got error %v, wanted a ParseError", err)
+			}
+		})
+	}
+}
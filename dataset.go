@@ -0,0 +1,265 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iand/gordf"
+)
+
+// An Order determines the order in which the quads in a Dataset are iterated and written.
+type Order int
+
+const (
+	// InsertionOrder orders quads by when they were first added to the Dataset.
+	InsertionOrder Order = iota
+
+	// TermOrder orders quads by subject, then predicate, object and graph, comparing terms as described
+	// by CompareTerms. Output produced in this order is the same for the same set of quads regardless of
+	// how they were added.
+	TermOrder
+)
+
+// A Dataset is an in-memory set of quads. Adding a quad that is already present has no effect.
+//
+// As returned by NewDataset, a Dataset iterates its quads in insertion order. The Order field can be
+// changed to select a different order before the dataset is used.
+//
+// A Dataset is safe for concurrent use by multiple goroutines. Long running readers, such as those serving
+// queries while a loader adds quads, should use Snapshot to obtain a point-in-time copy of the dataset which
+// can be read without contending with writers.
+type Dataset struct {
+	Order Order
+
+	mu      sync.RWMutex
+	quads   []Quad
+	index   map[Quad]struct{}
+	indexed bool         // whether index holds every quad in quads
+	terms   *termIndexes // if not nil, indexes every quad in quads by its terms
+}
+
+// NewDataset returns a new empty Dataset.
+func NewDataset() *Dataset {
+	return &Dataset{}
+}
+
+// LoadDataset reads all the quads from r, configured using the supplied options, into a new Dataset.
+// Duplicate quads are added once. Use WithProgress to report progress while loading a large input. If an
+// error is encountered the dataset holds the quads read before it, and the error is returned with it.
+func LoadDataset(r io.Reader, opts ...Option) (*Dataset, error) {
+	d := NewDataset()
+	qr := NewReader(r, opts...)
+	var q Quad
+	for qr.NextInto(&q) {
+		d.Add(q)
+	}
+	return d, qr.Err()
+}
+
+// Add adds q to the dataset and reports whether it was not already present. An object of kind
+// EscapedLiteralTerm is decoded before it is added, so that the quad is the same as when read without
+// WithEscapedLiterals.
+func (d *Dataset) Add(q Quad) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buildIndex()
+	return d.add(q)
+}
+
+// add adds q to the dataset and reports whether it was not already present. The caller must hold the write
+// lock and have called buildIndex.
+func (d *Dataset) add(q Quad) bool {
+	q = unescapedQuad(q)
+	if _, ok := d.index[q]; ok {
+		return false
+	}
+	d.index[q] = struct{}{}
+	d.quads = append(d.quads, q)
+	if d.terms != nil {
+		d.terms.add(q, len(d.quads)-1)
+	}
+	return true
+}
+
+// Has reports whether q is present in the dataset. An object of kind EscapedLiteralTerm is compared by
+// its decoded value.
+func (d *Dataset) Has(q Quad) bool {
+	q = unescapedQuad(q)
+	d.mu.RLock()
+	if d.indexed {
+		_, ok := d.index[q]
+		d.mu.RUnlock()
+		return ok
+	}
+	d.mu.RUnlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buildIndex()
+	_, ok := d.index[q]
+	return ok
+}
+
+// Len returns the number of quads in the dataset.
+func (d *Dataset) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.quads)
+}
+
+// Quads returns the quads in the dataset in the order determined by d.Order. The returned slice may be
+// modified by the caller.
+func (d *Dataset) Quads() []Quad {
+	d.mu.RLock()
+	quads := make([]Quad, len(d.quads))
+	copy(quads, d.quads)
+	d.mu.RUnlock()
+
+	if d.Order == TermOrder {
+		sortQuads(quads)
+	}
+	return quads
+}
+
+// Snapshot returns a copy of the dataset as it is at the time of the call. The copy shares storage with the
+// dataset, so taking a snapshot is cheap, and neither is affected by quads subsequently added to the other.
+func (d *Dataset) Snapshot() *Dataset {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	// Capping the capacity of the shared slice ensures that an append to either dataset
+	// cannot overwrite quads visible to the other. The index is rebuilt by the snapshot
+	// when it is first needed.
+	n := len(d.quads)
+	return &Dataset{
+		Order: d.Order,
+		quads: d.quads[:n:n],
+	}
+}
+
+// buildIndex ensures that d.index holds every quad in d.quads. The caller must hold the write lock.
+func (d *Dataset) buildIndex() {
+	if d.indexed {
+		return
+	}
+	d.index = make(map[Quad]struct{}, len(d.quads))
+	for _, q := range d.quads {
+		d.index[q] = struct{}{}
+	}
+	d.indexed = true
+}
+
+// WriteTo writes the quads in the dataset to w in N-Quads format, in the order determined by d.Order.
+// It returns the number of bytes written.
+func (d *Dataset) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	nw := NewWriter(cw)
+	for _, q := range d.Quads() {
+		if err := nw.Write(q); err != nil {
+			return cw.n, err
+		}
+	}
+	nw.Flush()
+	return cw.n, nw.Error()
+}
+
+// ReadFrom reads quads in N-Quads format from r until the end of the input and adds them to the dataset.
+// It returns the number of bytes read. If an error is encountered the quads read before it remain in the
+// dataset. Use LoadDataset to read an input using options.
+func (d *Dataset) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	qr := NewReader(cr)
+	var q Quad
+	for qr.NextInto(&q) {
+		d.Add(q)
+	}
+	return cr.n, qr.Err()
+}
+
+// countingReader is an io.Reader that counts the bytes read from an underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// countingWriter is an io.Writer that counts the bytes written to an underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// CompareTerms returns an integer comparing two terms. The result will be 0 if a == b, -1 if a < b, and +1
+// if a > b. Terms of unknown kind sort first, followed by literals, quoted triples, IRIs and then blank nodes,
+// matching the order of the start of their N-Quads serialization. Terms of the same kind are ordered by value,
+// then language tag and then datatype IRI.
+func CompareTerms(a, b rdf.Term) int {
+	if ra, rb := kindRank(a.Kind), kindRank(b.Kind); ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	if c := strings.Compare(a.Value, b.Value); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Language, b.Language); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Datatype, b.Datatype)
+}
+
+// CompareQuads returns an integer comparing two quads by subject, then predicate, object and graph using
+// CompareTerms. The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+func CompareQuads(a, b Quad) int {
+	if c := CompareTerms(a.S, b.S); c != 0 {
+		return c
+	}
+	if c := CompareTerms(a.P, b.P); c != 0 {
+		return c
+	}
+	if c := CompareTerms(a.O, b.O); c != 0 {
+		return c
+	}
+	return CompareTerms(a.G, b.G)
+}
+
+// sortQuads sorts quads using CompareQuads.
+func sortQuads(quads []Quad) {
+	sort.Slice(quads, func(i, j int) bool {
+		return CompareQuads(quads[i], quads[j]) < 0
+	})
+}
+
+func kindRank(kind int) int {
+	switch kind {
+	case rdf.LiteralTerm, EscapedLiteralTerm:
+		return 1
+	case QuotedTripleTerm:
+		return 2
+	case rdf.IRITerm:
+		return 3
+	case rdf.BlankTerm:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,254 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+const datasetInput = `<http://example/s2> <http://example/p> "b" .
+_:b1 <http://example/p> <http://example/o> <http://example/g> .
+<http://example/s1> <http://example/p> "z" <http://example/g> .
+<http://example/s1> <http://example/p> "a"@en .
+<http://example/s2> <http://example/p> "b" .
+<http://example/s1> <http://example/p> "a" .
+<http://example/s1> <http://example/p> <http://example/o> .
+<http://example/s1> <http://example/p> "z" .
+`
+
+func loadTestDataset(t *testing.T, input string) *Dataset {
+	t.Helper()
+	d := NewDataset()
+	r := NewReader(strings.NewReader(input))
+	for r.Next() {
+		d.Add(r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	return d
+}
+
+func TestLoadDataset(t *testing.T) {
+	var progress int64
+	d, err := LoadDataset(strings.NewReader(datasetInput), WithProgress(4, func(s ReaderStats) { progress = s.Quads }))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if d.Len() != 7 {
+		t.Errorf("got %d quads, wanted 7", d.Len())
+	}
+	if progress != 8 {
+		t.Errorf("got last progress at %d quads, wanted 8", progress)
+	}
+
+	d, err = LoadDataset(strings.NewReader(datasetInput + "bad\n"))
+	if err == nil {
+		t.Errorf("got no error for invalid input")
+	}
+	if d.Len() != 7 {
+		t.Errorf("got %d quads before the error, wanted 7", d.Len())
+	}
+}
+
+func TestDatasetReadFrom(t *testing.T) {
+	want := loadTestDataset(t, datasetInput)
+	var buf bytes.Buffer
+	if _, err := want.WriteTo(&buf); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	size := buf.Len()
+
+	d := NewDataset()
+	n, err := d.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if n != int64(size) {
+		t.Errorf("got %d bytes read, wanted %d", n, size)
+	}
+	if got := d.Quads(); !slices.Equal(got, want.Quads()) {
+		t.Errorf("got %v, wanted %v", got, want.Quads())
+	}
+
+	if _, err := d.ReadFrom(strings.NewReader("<http://example/s> <http://example/p> \"new\" .\nbad\n")); err == nil {
+		t.Errorf("got no error for invalid input")
+	}
+	if d.Len() != 8 {
+		t.Errorf("got %d quads, wanted 8", d.Len())
+	}
+}
+
+func TestDatasetAdd(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	if d.Len() != 7 {
+		t.Errorf("got %d quads, wanted 7", d.Len())
+	}
+
+	q := Quad{S: rdf.IRI("http://example/s2"), P: rdf.IRI("http://example/p"), O: rdf.Literal("b")}
+	if !d.Has(q) {
+		t.Errorf("dataset does not have %s", q)
+	}
+	if d.Add(q) {
+		t.Errorf("duplicate quad was added")
+	}
+}
+
+func TestDatasetWriteToInsertionOrder(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+
+	var buf bytes.Buffer
+	n, err := d.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("got %d bytes reported, wanted %d", n, buf.Len())
+	}
+
+	want := `<http://example/s2> <http://example/p> "b" .
+_:b1 <http://example/p> <http://example/o> <http://example/g> .
+<http://example/s1> <http://example/p> "z" <http://example/g> .
+<http://example/s1> <http://example/p> "a"@en .
+<http://example/s1> <http://example/p> "a" .
+<http://example/s1> <http://example/p> <http://example/o> .
+<http://example/s1> <http://example/p> "z" .
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestDatasetWriteToTermOrder(t *testing.T) {
+	want := `<http://example/s1> <http://example/p> "a" .
+<http://example/s1> <http://example/p> "a"@en .
+<http://example/s1> <http://example/p> "z" .
+<http://example/s1> <http://example/p> "z" <http://example/g> .
+<http://example/s1> <http://example/p> <http://example/o> .
+<http://example/s2> <http://example/p> "b" .
+_:b1 <http://example/p> <http://example/o> <http://example/g> .
+`
+
+	// The same quads added in a different order must produce the same output
+	lines := strings.SplitAfter(datasetInput, "\n")
+	reversed := ""
+	for i := len(lines) - 1; i >= 0; i-- {
+		reversed += lines[i]
+	}
+
+	for _, input := range []string{datasetInput, reversed} {
+		d := loadTestDataset(t, input)
+		d.Order = TermOrder
+
+		var buf bytes.Buffer
+		if _, err := d.WriteTo(&buf); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+		}
+	}
+}
+
+func TestCompareTerms(t *testing.T) {
+	ordered := []rdf.Term{
+		{},
+		rdf.Literal("a"),
+		rdf.LiteralWithDatatype("a", "http://example/dt"),
+		rdf.LiteralWithLanguage("a", "en"),
+		rdf.Literal("b"),
+		rdf.IRI("http://example/a"),
+		rdf.IRI("http://example/b"),
+		rdf.Blank("a"),
+	}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := CompareTerms(ordered[i], ordered[j]); got != want {
+				t.Errorf("CompareTerms(%v, %v): got %d, wanted %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestDatasetSnapshot(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	snap := d.Snapshot()
+
+	added := Quad{S: rdf.IRI("http://example/s3"), P: rdf.IRI("http://example/p"), O: rdf.Literal("c")}
+	d.Add(added)
+
+	if snap.Len() != 7 {
+		t.Errorf("got %d quads in snapshot, wanted 7", snap.Len())
+	}
+	if snap.Has(added) {
+		t.Errorf("snapshot has quad added to dataset after it was taken")
+	}
+
+	other := Quad{S: rdf.IRI("http://example/s4"), P: rdf.IRI("http://example/p"), O: rdf.Literal("d")}
+	if !snap.Add(other) {
+		t.Errorf("quad was not added to snapshot")
+	}
+	if d.Has(other) {
+		t.Errorf("dataset has quad added to snapshot")
+	}
+	if got := d.Quads()[7]; got != added {
+		t.Errorf("got quad %s in dataset, wanted %s", got, added)
+	}
+}
+
+func TestDatasetConcurrentReaders(t *testing.T) {
+	d := NewDataset()
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snap := d.Snapshot()
+				n := 0
+				for _, q := range snap.Quads() {
+					if !snap.Has(q) {
+						t.Errorf("snapshot is missing %s", q)
+					}
+					n++
+				}
+				if n != snap.Len() {
+					t.Errorf("got %d quads, wanted %d", n, snap.Len())
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		d.Add(Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal(strconv.Itoa(i))})
+	}
+	close(done)
+	wg.Wait()
+
+	if d.Len() != 1000 {
+		t.Errorf("got %d quads, wanted 1000", d.Len())
+	}
+}
@@ -0,0 +1,94 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"sort"
+
+	"github.com/iand/gordf"
+)
+
+// maxTopPredicates is the number of predicates reported in DatasetStats.TopPredicates.
+const maxTopPredicates = 10
+
+// DatasetStats describes the contents of a Dataset, as returned by Dataset.Stats.
+type DatasetStats struct {
+	Quads      int // the number of quads
+	Subjects   int // the number of distinct subjects
+	Predicates int // the number of distinct predicates
+	Objects    int // the number of distinct objects
+
+	Graphs map[rdf.Term]int // the number of quads in each graph, with the default graph as DefaultGraph
+
+	SubjectKinds TermKinds // the number of quads with each kind of subject
+	ObjectKinds  TermKinds // the number of quads with each kind of object
+
+	// TopPredicates holds the most frequently used predicates, at most 10, ordered by descending count.
+	// Predicates with equal counts are ordered as by CompareTerms.
+	TopPredicates []TermCount
+}
+
+// TermKinds counts terms by their kind.
+type TermKinds struct {
+	IRIs          int
+	BlankNodes    int
+	Literals      int
+	QuotedTriples int
+}
+
+// add adds n to the count of the kind of t.
+func (k *TermKinds) add(t rdf.Term, n int) {
+	switch t.Kind {
+	case rdf.IRITerm:
+		k.IRIs += n
+	case rdf.BlankTerm:
+		k.BlankNodes += n
+	case rdf.LiteralTerm, EscapedLiteralTerm:
+		k.Literals += n
+	case QuotedTripleTerm:
+		k.QuotedTriples += n
+	}
+}
+
+// Stats returns statistics describing the quads in the dataset, for profiling an unfamiliar dataset. It
+// uses the indexes built by Find, building them if needed.
+func (d *Dataset) Stats() DatasetStats {
+	x := d.indexes()
+	defer d.mu.RUnlock()
+
+	s := DatasetStats{
+		Quads:      len(d.quads),
+		Subjects:   len(x.spog),
+		Predicates: len(x.posg),
+		Objects:    len(x.ospg),
+		Graphs:     make(map[rdf.Term]int, len(x.graphs)),
+	}
+	for g, positions := range x.graphs {
+		s.Graphs[g] = len(positions)
+	}
+	for t, tp := range x.spog {
+		s.SubjectKinds.add(t, len(tp.all))
+	}
+	for t, tp := range x.ospg {
+		s.ObjectKinds.add(t, len(tp.all))
+	}
+
+	predicates := make([]TermCount, 0, len(x.posg))
+	for t, tp := range x.posg {
+		predicates = append(predicates, TermCount{Term: t, Count: int64(len(tp.all))})
+	}
+	sort.Slice(predicates, func(i, j int) bool {
+		if predicates[i].Count != predicates[j].Count {
+			return predicates[i].Count > predicates[j].Count
+		}
+		return CompareTerms(predicates[i].Term, predicates[j].Term) < 0
+	})
+	if len(predicates) > maxTopPredicates {
+		predicates = predicates[:maxTopPredicates]
+	}
+	s.TopPredicates = predicates
+	return s
+}
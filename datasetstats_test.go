@@ -0,0 +1,71 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestDatasetStats(t *testing.T) {
+	input := datasetInput +
+		"<< _:b1 <http://example/p> \"x\" >> <http://example/q> _:b1 .\n" +
+		"<http://example/s1> <http://example/q> <http://example/s2> .\n" +
+		"<http://example/s1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/C> <http://example/g> .\n"
+
+	got := loadTestDataset(t, input).Stats()
+	want := DatasetStats{
+		Quads:      10,
+		Subjects:   4,
+		Predicates: 3,
+		Objects:    8,
+		Graphs: map[rdf.Term]int{
+			DefaultGraph:                7,
+			rdf.IRI("http://example/g"): 3,
+		},
+		SubjectKinds: TermKinds{IRIs: 8, BlankNodes: 1, QuotedTriples: 1},
+		ObjectKinds:  TermKinds{IRIs: 4, BlankNodes: 1, Literals: 5},
+		TopPredicates: []TermCount{
+			{Term: rdf.IRI("http://example/p"), Count: 7},
+			{Term: rdf.IRI("http://example/q"), Count: 2},
+			{Term: rdf.IRI("http://www.w3.org/1999/02/22-rdf-syntax-ns#type"), Count: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestDatasetStatsTopPredicates(t *testing.T) {
+	var b strings.Builder
+	for i := range 15 {
+		for j := range i + 1 {
+			fmt.Fprintf(&b, "<http://example/s> <http://example/p%02d> \"%d\" .\n", i, j)
+		}
+	}
+
+	got := loadTestDataset(t, b.String()).Stats().TopPredicates
+	if len(got) != maxTopPredicates {
+		t.Fatalf("got %d predicates, wanted %d", len(got), maxTopPredicates)
+	}
+	for i, tc := range got {
+		want := TermCount{Term: rdf.IRI(fmt.Sprintf("http://example/p%02d", 14-i)), Count: int64(15 - i)}
+		if tc != want {
+			t.Errorf("predicate %d: got %+v, wanted %+v", i, tc, want)
+		}
+	}
+}
+
+func TestDatasetStatsEmpty(t *testing.T) {
+	got := NewDataset().Stats()
+	if got.Quads != 0 || got.Subjects != 0 || len(got.Graphs) != 0 || len(got.TopPredicates) != 0 {
+		t.Errorf("got %+v, wanted empty statistics", got)
+	}
+}
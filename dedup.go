@@ -0,0 +1,59 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"container/list"
+)
+
+// WithDedup configures the Reader to skip any quad that is identical to one of the last window distinct
+// quads it returned. Sorted inputs and inputs concatenated from overlapping sources often contain runs of
+// duplicates, which are removed without the memory needed to remember every quad. Duplicates further apart
+// than window distinct quads are not detected. Blank nodes are compared by label.
+func WithDedup(window int) Option {
+	return func(r *Reader) {
+		if window > 0 {
+			r.dedup = newRecentQuads(window)
+		}
+	}
+}
+
+// recentQuads remembers up to max distinct quads, discarding the least recently seen when full.
+type recentQuads struct {
+	max   int
+	quads map[Quad]*list.Element
+	lru   list.List // of Quad, most recently seen at the front
+}
+
+func newRecentQuads(max int) *recentQuads {
+	return &recentQuads{
+		max:   max,
+		quads: make(map[Quad]*list.Element),
+	}
+}
+
+// add adds q to the set, returning false if it was already present. Escaped literals are compared by their
+// decoded values.
+func (rq *recentQuads) add(q Quad) bool {
+	q = unescapedQuad(q)
+	if el, ok := rq.quads[q]; ok {
+		rq.lru.MoveToFront(el)
+		return false
+	}
+	if len(rq.quads) >= rq.max {
+		oldest := rq.lru.Back()
+		rq.lru.Remove(oldest)
+		delete(rq.quads, oldest.Value.(Quad))
+	}
+	rq.quads[q] = rq.lru.PushFront(q)
+	return true
+}
+
+// reset removes all quads from the set.
+func (rq *recentQuads) reset() {
+	clear(rq.quads)
+	rq.lru.Init()
+}
@@ -0,0 +1,44 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	line := func(o string) string {
+		return "<http://example/s> <http://example/p> \"" + o + "\" .\n"
+	}
+	input := line("a") + line("a") + line("b") + line("a") + line("c") + line("d") + line("a") +
+		"<http://example/s> <http://example/p> \"a\" <http://example/g> .\n"
+
+	testCases := []struct {
+		window int
+		want   string
+	}{
+		{window: 0, want: "a a b a c d a a"},
+		{window: 1, want: "a b a c d a a"},
+		{window: 2, want: "a b c d a a"},
+		{window: 3, want: "a b c d a"},
+		{window: 4, want: "a b c d a"},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(input), WithDedup(tc.window))
+		var got []string
+		for r.Next() {
+			got = append(got, r.Quad().O.Value)
+		}
+		if r.Err() != nil {
+			t.Fatalf("window %d: got unexpected error %q", tc.window, r.Err())
+		}
+		if strings.Join(got, " ") != tc.want {
+			t.Errorf("window %d: got %q, wanted %q", tc.window, strings.Join(got, " "), tc.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// Diff compares two datasets, typically two versions of the same data, and returns the quads that are in
+// b but not in a as added and the quads that are in a but not in b as removed. Added quads are in the
+// order determined by b.Order and removed quads in the order determined by a.Order.
+//
+// Blank nodes are compared by their labels, so two datasets that differ only in the labelling of their
+// blank nodes are reported as different. Use DiffBlankNodes to compare them by their structure instead.
+func Diff(a, b *Dataset) (added, removed []Quad) {
+	for _, q := range b.Quads() {
+		if !a.Has(q) {
+			added = append(added, q)
+		}
+	}
+	for _, q := range a.Quads() {
+		if !b.Has(q) {
+			removed = append(removed, q)
+		}
+	}
+	return added, removed
+}
+
+// DiffBlankNodes is like Diff but compares the quads of the datasets after relabelling their blank nodes
+// as described by Canonicalize, so that the labels chosen for blank nodes by the producers of the datasets
+// do not affect the result. The quads returned have the blank node labels of the dataset they are taken
+// from. Canonical labels depend on the structure of the whole dataset, so a change that affects blank
+// nodes may cause other quads that mention blank nodes to be reported as removed and added.
+//
+// ErrCanonicalizationLimit is returned if either dataset requires an excessive amount of work to
+// canonicalize.
+func DiffBlankNodes(a, b *Dataset) (added, removed []Quad, err error) {
+	aquads, acanon, err := canonicalForms(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	bquads, bcanon, err := canonicalForms(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aset := make(map[Quad]struct{}, len(acanon))
+	for _, q := range acanon {
+		aset[q] = struct{}{}
+	}
+	bset := make(map[Quad]struct{}, len(bcanon))
+	for _, q := range bcanon {
+		bset[q] = struct{}{}
+	}
+
+	for i, q := range bcanon {
+		if _, ok := aset[q]; !ok {
+			added = append(added, bquads[i])
+		}
+	}
+	for i, q := range acanon {
+		if _, ok := bset[q]; !ok {
+			removed = append(removed, aquads[i])
+		}
+	}
+	return added, removed, nil
+}
+
+// canonicalForms returns the quads of d, in the order determined by d.Order, and the same quads with their
+// blank nodes relabelled as described by Canonicalize.
+func canonicalForms(d *Dataset) (quads, canon []Quad, err error) {
+	quads = d.Quads()
+	c := newCanonicalizer(quads)
+	if err := c.run(); err != nil {
+		return nil, nil, err
+	}
+	canon = make([]Quad, len(quads))
+	for i, q := range quads {
+		canon[i] = Quad{S: c.relabel(q.S), P: q.P, O: c.relabel(q.O), G: c.relabel(q.G)}
+	}
+	return quads, canon, nil
+}
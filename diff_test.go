@@ -0,0 +1,107 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a, b    string
+		added   string
+		removed string
+	}{
+		{
+			name: "identical",
+			a:    datasetInput,
+			b:    datasetInput,
+		},
+		{
+			name:  "added",
+			a:     "<http://example/s> <http://example/p> \"a\" .\n",
+			b:     "<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" <http://example/g> .\n",
+			added: "<http://example/s> <http://example/p> \"b\" <http://example/g> .\n",
+		},
+		{
+			name:    "removed",
+			a:       "<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" .\n",
+			b:       "<http://example/s> <http://example/p> \"b\" .\n",
+			removed: "<http://example/s> <http://example/p> \"a\" .\n",
+		},
+		{
+			name:    "changed literal",
+			a:       "<http://example/s> <http://example/p> \"a\" .\n",
+			b:       "<http://example/s> <http://example/p> \"a\"@en .\n",
+			added:   "<http://example/s> <http://example/p> \"a\"@en .\n",
+			removed: "<http://example/s> <http://example/p> \"a\" .\n",
+		},
+		{
+			name:    "relabelled blank node",
+			a:       "_:a <http://example/p> \"a\" .\n",
+			b:       "_:b <http://example/p> \"a\" .\n",
+			added:   "_:b <http://example/p> \"a\" .\n",
+			removed: "_:a <http://example/p> \"a\" .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, removed := Diff(loadTestDataset(t, tc.a), loadTestDataset(t, tc.b))
+			if want := loadTestDataset(t, tc.added).Quads(); !slices.Equal(added, want) {
+				t.Errorf("got added %v, wanted %v", added, want)
+			}
+			if want := loadTestDataset(t, tc.removed).Quads(); !slices.Equal(removed, want) {
+				t.Errorf("got removed %v, wanted %v", removed, want)
+			}
+		})
+	}
+}
+
+func TestDiffBlankNodes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a, b    string
+		added   string
+		removed string
+	}{
+		{
+			name: "relabelled blank nodes",
+			a:    "_:a <http://example/p> _:b .\n_:b <http://example/p> \"x\" _:g .\n",
+			b:    "_:n1 <http://example/p> _:n2 .\n_:n2 <http://example/p> \"x\" _:n3 .\n",
+		},
+		{
+			name:  "added to blank node",
+			a:     "_:a <http://example/p> \"x\" .\n<http://example/s> <http://example/p> \"y\" .\n",
+			b:     "_:b <http://example/p> \"x\" .\n_:b <http://example/p> \"z\" .\n<http://example/s> <http://example/p> \"y\" .\n",
+			added: "_:b <http://example/p> \"z\" .\n",
+		},
+		{
+			name:    "changed iri",
+			a:       "_:a <http://example/p> <http://example/o1> .\n",
+			b:       "_:a <http://example/p> <http://example/o2> .\n",
+			added:   "_:a <http://example/p> <http://example/o2> .\n",
+			removed: "_:a <http://example/p> <http://example/o1> .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, removed, err := DiffBlankNodes(loadTestDataset(t, tc.a), loadTestDataset(t, tc.b))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if want := loadTestDataset(t, tc.added).Quads(); !slices.Equal(added, want) {
+				t.Errorf("got added %v, wanted %v", added, want)
+			}
+			if want := loadTestDataset(t, tc.removed).Quads(); !slices.Equal(removed, want) {
+				t.Errorf("got removed %v, wanted %v", removed, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/iand/gordf"
+)
+
+// ErrInvalidDirection is the error returned in ParseError.Err when the base direction of a literal is
+// neither ltr nor rtl.
+var ErrInvalidDirection = errors.New("invalid base direction")
+
+// The base directions of a directional language-tagged string.
+const (
+	DirectionLTR = "ltr" // left to right
+	DirectionRTL = "rtl" // right to left
+)
+
+// WithRDF11 configures the Reader to accept only the N-Quads syntax defined by RDF 1.1, rejecting the base
+// directions of RDF 1.2 language tags and RDF-star quoted triples with ErrUnexpectedCharacter.
+func WithRDF11() Option {
+	return func(r *Reader) {
+		r.rdf11 = true
+	}
+}
+
+// LiteralWithDirection returns a literal term with the given language tag and base direction, which
+// should be DirectionLTR or DirectionRTL. As in N-Quads, the direction is held in the Language field of the
+// term, separated from the tag by "--", so that the term can be written and compared like any other
+// language-tagged literal.
+func LiteralWithDirection(value, language, direction string) rdf.Term {
+	return rdf.LiteralWithLanguage(value, language+"--"+direction)
+}
+
+// SplitDirection splits the language tag of a literal into the language and base direction. The direction
+// is empty if the tag has none.
+func SplitDirection(tag string) (language, direction string) {
+	language, direction, _ = strings.Cut(tag, "--")
+	return language, direction
+}
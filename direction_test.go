@@ -0,0 +1,88 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestLanguageTags(t *testing.T) {
+	testCases := []struct {
+		object string
+		opts   []Option
+		want   rdf.Term
+		err    error
+	}{
+		{object: `"x"@en`, want: rdf.LiteralWithLanguage("x", "en")},
+		{object: `"x"@en-GB`, want: rdf.LiteralWithLanguage("x", "en-GB")},
+		{object: `"x"@zh-Hant-TW`, want: rdf.LiteralWithLanguage("x", "zh-Hant-TW")},
+		{object: `"x"@es-419`, want: rdf.LiteralWithLanguage("x", "es-419")},
+		{object: `"x"@ar--rtl`, want: LiteralWithDirection("x", "ar", DirectionRTL)},
+		{object: `"x"@en-US--ltr`, want: LiteralWithDirection("x", "en-US", DirectionLTR)},
+		{object: `"x"@ar--rtl`, opts: []Option{WithRDF11()}, err: ErrUnexpectedCharacter},
+		{object: `"x"@en--up`, err: ErrInvalidDirection},
+		{object: `"x"@en--LTR`, err: ErrInvalidDirection},
+		{object: `"x"@en--`, err: ErrUnexpectedCharacter},
+		{object: `"x"@en---ltr`, err: ErrUnexpectedCharacter},
+		{object: `"x"@en--ltr-x`, err: ErrUnexpectedCharacter},
+		{object: `"x"@en-`, err: ErrUnexpectedCharacter},
+		{object: `"x"@-en`, err: ErrUnexpectedCharacter},
+		{object: `"x"@`, err: ErrUnexpectedCharacter},
+		{object: `"x"@1en`, err: ErrUnexpectedCharacter},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(`<http://example/s> <http://example/p> `+tc.object+" .\n"), tc.opts...)
+		r.Next()
+		if tc.err != nil {
+			if !errors.Is(r.Err(), tc.err) {
+				t.Errorf("%s: got error %v, wanted %v", tc.object, r.Err(), tc.err)
+			}
+			continue
+		}
+		if r.Err() != nil {
+			t.Errorf("%s: got unexpected error %q", tc.object, r.Err())
+			continue
+		}
+		if got := r.Quad().O; got != tc.want {
+			t.Errorf("%s: got %#v, wanted %#v", tc.object, got, tc.want)
+		}
+	}
+}
+
+func TestRDF11QuotedTriple(t *testing.T) {
+	r := NewReader(strings.NewReader("<< <http://example/s> <http://example/p> <http://example/o> >> <http://example/p> <http://example/o> .\n"), WithRDF11())
+	if r.Next() {
+		t.Fatalf("got unexpected quad %v", r.Quad())
+	}
+	if !errors.Is(r.Err(), ErrUnexpectedCharacter) {
+		t.Errorf("got error %v, wanted %v", r.Err(), ErrUnexpectedCharacter)
+	}
+}
+
+func TestSplitDirection(t *testing.T) {
+	testCases := []struct {
+		tag       string
+		language  string
+		direction string
+	}{
+		{tag: "en", language: "en"},
+		{tag: "en-GB", language: "en-GB"},
+		{tag: "ar--rtl", language: "ar", direction: "rtl"},
+		{tag: "en-US--ltr", language: "en-US", direction: "ltr"},
+	}
+
+	for _, tc := range testCases {
+		language, direction := SplitDirection(tc.tag)
+		if language != tc.language || direction != tc.direction {
+			t.Errorf("%s: got %q, %q, wanted %q, %q", tc.tag, language, direction, tc.language, tc.direction)
+		}
+	}
+}
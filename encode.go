@@ -0,0 +1,183 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/iand/gordf"
+)
+
+const hexDigits = "0123456789ABCDEF"
+
+// ErrIncompleteQuad is the error returned when encoding a quad whose subject, predicate or object is of
+// unknown kind.
+var ErrIncompleteQuad = errors.New("quad has no subject, predicate or object")
+
+// MarshalText implements the encoding.TextMarshaler interface. The encoding is the N-Quads statement for q
+// without a terminating newline.
+func (q Quad) MarshalText() ([]byte, error) {
+	return q.AppendText(nil)
+}
+
+// AppendText appends the N-Quads statement for q to b, without a terminating newline, and returns the
+// extended buffer. It returns ErrIncompleteQuad if q has no subject, predicate or object.
+func (q Quad) AppendText(b []byte) ([]byte, error) {
+	if q.S.Kind == rdf.UnknownTerm || q.P.Kind == rdf.UnknownTerm || q.O.Kind == rdf.UnknownTerm {
+		return b, ErrIncompleteQuad
+	}
+	return AppendQuad(b, q), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text must contain exactly one
+// N-Quads statement, which may be surrounded by whitespace, and is decoded as described by DecodeMessage.
+func (q *Quad) UnmarshalText(text []byte) error {
+	decoded, err := DecodeMessage(bytes.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	*q = decoded
+	return nil
+}
+
+// AppendQuad appends the N-Quads serialization of q to dst and returns the extended buffer. The statement
+// is terminated by a '.' but not by a newline. The graph term is omitted if it is of unknown kind, denoting
+// the default graph. No allocations are made if dst has sufficient capacity.
+func AppendQuad(dst []byte, q Quad) []byte {
+	dst = appendTerms(dst, q)
+	return append(dst, " ."...)
+}
+
+// appendTerms appends the space separated terms of q to dst, omitting the graph term if it is
+// of unknown kind.
+func appendTerms(dst []byte, q Quad) []byte {
+	dst = AppendTerm(dst, q.S)
+	dst = append(dst, ' ')
+	dst = AppendTerm(dst, q.P)
+	dst = append(dst, ' ')
+	dst = AppendTerm(dst, q.O)
+	if q.G.Kind != rdf.UnknownTerm {
+		dst = append(dst, ' ')
+		dst = AppendTerm(dst, q.G)
+	}
+	return dst
+}
+
+// AppendTerm appends the N-Quads serialization of t to dst and returns the extended buffer. Nothing is
+// appended for a term of unknown kind. No allocations are made if dst has sufficient capacity.
+func AppendTerm(dst []byte, t rdf.Term) []byte {
+	switch t.Kind {
+	case rdf.IRITerm:
+		return appendIRI(dst, t.Value)
+	case rdf.BlankTerm:
+		dst = append(dst, "_:"...)
+		return append(dst, t.Value...)
+	case rdf.LiteralTerm, EscapedLiteralTerm:
+		if t.Kind == EscapedLiteralTerm {
+			dst = append(dst, '"')
+			dst = append(dst, t.Value...)
+			dst = append(dst, '"')
+		} else {
+			dst = appendString(dst, t.Value)
+		}
+		if t.Language != "" {
+			dst = append(dst, '@')
+			dst = append(dst, t.Language...)
+		} else if t.Datatype != "" {
+			dst = append(dst, "^^"...)
+			dst = appendIRI(dst, t.Datatype)
+		}
+		return dst
+	case QuotedTripleTerm:
+		dst = append(dst, "<< "...)
+		dst = append(dst, t.Value...)
+		return append(dst, " >>"...)
+	default:
+		return dst
+	}
+}
+
+// appendIRI appends iri enclosed in angle brackets to dst, escaping any characters that
+// are not permitted in an IRIREF using \u or \U escapes. Runs of ASCII characters that need
+// no escape are copied in one step.
+func appendIRI(dst []byte, iri string) []byte {
+	dst = append(dst, '<')
+	for i := 0; i < len(iri); {
+		j := i
+		for j < len(iri) && isIRIByte(iri[j]) {
+			j++
+		}
+		dst = append(dst, iri[i:j]...)
+		if j == len(iri) {
+			break
+		}
+		r1, size := utf8.DecodeRuneInString(iri[j:])
+		if needsIRIEscape(r1) {
+			dst = appendCodepoint(dst, r1)
+		} else {
+			dst = utf8.AppendRune(dst, r1)
+		}
+		i = j + size
+	}
+	return append(dst, '>')
+}
+
+// needsIRIEscape reports whether r must be written as a \u or \U escape in an IRIREF.
+func needsIRIEscape(r rune) bool {
+	return r <= 0x20 || r == '<' || r == '>' || r == '"' || r == '{' || r == '}' || r == '|' || r == '^' || r == '`' || r == '\\'
+}
+
+// appendString appends s to dst as a quoted literal value. Only the double quote, backslash,
+// line feed and carriage return characters are escaped, following canonical N-Triples. The
+// text between them is copied in one step.
+func appendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for {
+		i := strings.IndexAny(s, "\"\\\n\r")
+		if i < 0 {
+			dst = append(dst, s...)
+			break
+		}
+		dst = append(dst, s[:i]...)
+		switch s[i] {
+		case '"':
+			dst = append(dst, '\\', '"')
+		case '\\':
+			dst = append(dst, '\\', '\\')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		}
+		s = s[i+1:]
+	}
+	return append(dst, '"')
+}
+
+// appendCodepoint appends r to dst as a \uXXXX escape, or a \UXXXXXXXX escape if r lies outside
+// the basic multilingual plane.
+func appendCodepoint(dst []byte, r rune) []byte {
+	if r > 0xFFFF {
+		dst = append(dst, '\\', 'U')
+		for shift := 28; shift >= 0; shift -= 4 {
+			dst = append(dst, hexDigits[(r>>uint(shift))&0xF])
+		}
+		return dst
+	}
+	dst = append(dst, '\\', 'u')
+	for shift := 12; shift >= 0; shift -= 4 {
+		dst = append(dst, hexDigits[(r>>uint(shift))&0xF])
+	}
+	return dst
+}
+
+// termText returns the N-Quads serialization of t, or the empty string for a term of unknown kind.
+func termText(t rdf.Term) string {
+	return string(AppendTerm(nil, t))
+}
@@ -0,0 +1,192 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestAppendTerm(t *testing.T) {
+	testCases := []struct {
+		term rdf.Term
+		want string
+	}{
+		{term: rdf.IRI("http://example/s"), want: `<http://example/s>`},
+		{term: rdf.IRI("http://example/a b\U0001F600"), want: `<http://example/a\u0020b` + "\U0001F600" + `>`},
+		{term: rdf.IRI("http://example/\x01"), want: `<http://example/\u0001>`},
+		{term: rdf.IRI("http://example/café{x}"), want: `<http://example/café\u007Bx\u007D>`},
+		{term: rdf.Blank("b1"), want: `_:b1`},
+		{term: rdf.Literal("a\"b\\c\nd\re\tf"), want: `"a\"b\\c\nd\re` + "\t" + `f"`},
+		{term: rdf.Literal("\"quoted\" and ünïcode\\"), want: `"\"quoted\" and ünïcode\\"`},
+		{term: rdf.LiteralWithLanguage("chat", "fr"), want: `"chat"@fr`},
+		{term: rdf.LiteralWithDatatype("1", "http://example/dt"), want: `"1"^^<http://example/dt>`},
+		{term: rdf.Term{}, want: ``},
+	}
+
+	for _, tc := range testCases {
+		prefix := []byte("prefix:")
+		got := string(AppendTerm(prefix, tc.term))
+		if got != "prefix:"+tc.want {
+			t.Errorf("got %s, wanted %s", got, "prefix:"+tc.want)
+		}
+
+		// The serialization must round trip through the parser
+		if tc.term.Kind != rdf.UnknownTerm {
+			parsed, err := ParseTerm(tc.want)
+			if err != nil {
+				t.Errorf("%s: failed to parse: %v", tc.want, err)
+			} else if parsed != tc.term {
+				t.Errorf("%s: parsed as %v, wanted %v", tc.want, parsed, tc.term)
+			}
+		}
+	}
+}
+
+func TestAppendQuadAllocations(t *testing.T) {
+	q := Quad{
+		S: rdf.IRI("http://example/s"),
+		P: rdf.IRI("http://example/p"),
+		O: rdf.LiteralWithLanguage("a \"quoted\" value", "en"),
+		G: rdf.Blank("g"),
+	}
+	buf := make([]byte, 0, 256)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendQuad(buf[:0], q)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations, wanted 0", allocs)
+	}
+}
+
+func TestQuadString(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		opts  []Option
+	}{
+		{
+			name:  "default graph",
+			input: `<http://example/s> <http://example/p> "a\"b"@en .`,
+		},
+		{
+			name:  "named graph",
+			input: `_:s <http://example/p> "1"^^<http://example/dt> <http://example/g> .`,
+		},
+		{
+			name:  "quoted triple",
+			input: `<< <http://example/s> <http://example/p> _:o >> <http://example/p> << _:s <http://example/p> "x" >> .`,
+		},
+		{
+			name:  "escaped literal",
+			input: `<http://example/s> <http://example/p> "a\tb\u00E9" .`,
+			opts:  []Option{WithEscapedLiterals()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.input+"\n"), tc.opts...)
+			if !r.Next() {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if got := r.Quad().String(); got != tc.input {
+				t.Errorf("got %s, wanted %s", got, tc.input)
+			}
+		})
+	}
+}
+
+func TestQuadMarshalText(t *testing.T) {
+	for _, tc := range messageCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.quad.MarshalText()
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if string(got) != tc.encoded {
+				t.Errorf("got %s, wanted %s", got, tc.encoded)
+			}
+
+			appended, err := tc.quad.AppendText([]byte("prefix:"))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if string(appended) != "prefix:"+tc.encoded {
+				t.Errorf("got %s, wanted %s", appended, "prefix:"+tc.encoded)
+			}
+		})
+	}
+}
+
+func TestQuadMarshalTextIncomplete(t *testing.T) {
+	q := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p")}
+	if _, err := q.MarshalText(); err != ErrIncompleteQuad {
+		t.Errorf("got error %v, wanted %v", err, ErrIncompleteQuad)
+	}
+}
+
+func TestQuadUnmarshalText(t *testing.T) {
+	for _, tc := range messageCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Quad
+			if err := got.UnmarshalText([]byte(" " + tc.encoded + "\n")); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if got != tc.quad {
+				t.Errorf("got %s, wanted %s", got, tc.quad)
+			}
+		})
+	}
+}
+
+func TestQuadUnmarshalTextInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+	}{
+		{name: "empty", text: ""},
+		{name: "comment", text: "# nothing here"},
+		{name: "two statements", text: "_:a <http://example/p> _:b .\n_:a <http://example/p> _:c ."},
+		{name: "unterminated", text: "_:a <http://example/p> _:b"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := messageCases[0].quad
+			if err := q.UnmarshalText([]byte(tc.text)); err == nil {
+				t.Errorf("got no error, wanted one")
+			}
+			if q != messageCases[0].quad {
+				t.Errorf("quad was modified on error")
+			}
+		})
+	}
+}
+
+func TestQuadJSON(t *testing.T) {
+	type record struct {
+		Statement Quad `json:"statement"`
+	}
+
+	in := record{Statement: messageCases[1].quad}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if out != in {
+		t.Errorf("got %s, wanted %s", out.Statement, in.Statement)
+	}
+}
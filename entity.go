@@ -0,0 +1,81 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"github.com/iand/gordf"
+)
+
+// An Entity holds the values of each predicate used with a single subject.
+type Entity struct {
+	Subject    rdf.Term
+	Properties map[string][]rdf.Term // values keyed by predicate IRI, in the order they were read
+}
+
+// Values returns the values of the predicate with the given IRI.
+func (e *Entity) Values(predicate string) []rdf.Term {
+	return e.Properties[predicate]
+}
+
+// Value returns the first value of the predicate with the given IRI and reports whether one was found.
+func (e *Entity) Value(predicate string) (rdf.Term, bool) {
+	values := e.Properties[predicate]
+	if len(values) == 0 {
+		return rdf.Term{}, false
+	}
+	return values[0], true
+}
+
+// An EntityReader assembles the quads read from a Reader into entities, one per subject. The input must be
+// grouped by subject so that all the quads for a subject are adjacent, which allows an entity to be
+// yielded as soon as it is complete and bounds memory use by the size of the largest entity. A subject that
+// appears in more than one group yields more than one entity. The graph of each quad is ignored.
+type EntityReader struct {
+	r   *Reader
+	e   *Entity
+	err error
+}
+
+// NewEntityReader returns a new EntityReader that reads quads from r.
+func NewEntityReader(r *Reader) *EntityReader {
+	return &EntityReader{r: r}
+}
+
+// Next attempts to assemble the next entity. It returns false if no entity could be assembled which may
+// indicate an error has occurred or the end of the input has been reached. If an error occurs part way
+// through an entity, the quads read before the error are returned as an entity before Next returns false.
+func (er *EntityReader) Next() bool {
+	er.e = nil
+	if er.err != nil {
+		return false
+	}
+
+	err := groupBySubject(er.r, true, func(s rdf.Term, quads []Quad) error {
+		e := &Entity{
+			Subject:    s,
+			Properties: make(map[string][]rdf.Term),
+		}
+		for _, q := range quads {
+			e.Properties[q.P.Value] = append(e.Properties[q.P.Value], q.O)
+		}
+		er.e = e
+		return errStopGroups
+	})
+	if err != errStopGroups {
+		er.err = err
+	}
+	return er.e != nil
+}
+
+// Entity returns the last entity assembled.
+func (er *EntityReader) Entity() *Entity {
+	return er.e
+}
+
+// Err returns any error encountered while reading.
+func (er *EntityReader) Err() error {
+	return er.err
+}
@@ -0,0 +1,70 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestEntityReader(t *testing.T) {
+	input := `<http://example/alice> <http://example/name> "Alice" <http://example/g1> .
+<http://example/alice> <http://example/knows> <http://example/bob> .
+<http://example/alice> <http://example/knows> _:carol <http://example/g2> .
+_:carol <http://example/name> "Carol" .
+<http://example/bob> <http://example/name> "Bob" .
+`
+	er := NewEntityReader(NewReader(strings.NewReader(input)))
+
+	var entities []*Entity
+	for er.Next() {
+		entities = append(entities, er.Entity())
+	}
+	if er.Err() != nil {
+		t.Fatalf("got unexpected error %q", er.Err())
+	}
+
+	if len(entities) != 3 {
+		t.Fatalf("got %d entities, wanted 3", len(entities))
+	}
+
+	alice := entities[0]
+	if alice.Subject != rdf.IRI("http://example/alice") {
+		t.Errorf("got subject %q, wanted alice", alice.Subject.Value)
+	}
+	if name, ok := alice.Value("http://example/name"); !ok || name != rdf.Literal("Alice") {
+		t.Errorf("got name %v, wanted Alice", name)
+	}
+	knows := alice.Values("http://example/knows")
+	if len(knows) != 2 || knows[0] != rdf.IRI("http://example/bob") || knows[1] != rdf.Blank("carol") {
+		t.Errorf("got knows %v, wanted bob and carol", knows)
+	}
+
+	if entities[1].Subject != rdf.Blank("carol") {
+		t.Errorf("got subject %q, wanted carol", entities[1].Subject.Value)
+	}
+	if _, ok := entities[2].Value("http://example/knows"); ok {
+		t.Errorf("got unexpected knows value for bob")
+	}
+}
+
+func TestEntityReaderError(t *testing.T) {
+	input := `<http://example/alice> <http://example/name> "Alice" .
+<http://example/alice> <http://example/name> "Alice
+`
+	er := NewEntityReader(NewReader(strings.NewReader(input)))
+	if !er.Next() {
+		t.Fatalf("got no entity, wanted partial entity before error")
+	}
+	if er.Next() {
+		t.Errorf("got unexpected entity")
+	}
+	if er.Err() == nil {
+		t.Errorf("got no error, wanted parse error")
+	}
+}
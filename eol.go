@@ -0,0 +1,68 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+)
+
+// ErrInconsistentLineEnding is the error returned in ParseError.Err when the Reader was configured using
+// WithStrictLineEndings and a line ends with a lone carriage return or differently from the first line.
+var ErrInconsistentLineEnding = errors.New("inconsistent line ending")
+
+// WithStrictLineEndings configures the Reader to require every line to end with \n, or every line to end
+// with \r\n, failing with ErrInconsistentLineEnding for a lone carriage return or for a line ending that
+// differs from the one ending the first line. By default lines may end with \n, \r\n or a lone \r, as
+// permitted by the N-Quads grammar, and the kinds may be mixed freely.
+func WithStrictLineEndings() Option {
+	return func(r *Reader) {
+		r.strictEOL = true
+	}
+}
+
+// isEOL reports whether r ends a line.
+func isEOL(r rune) bool {
+	return r == '\n' || r == '\r'
+}
+
+// checkEOL checks the line terminator r1 that has just been read against the line endings seen before,
+// returning an error if they are inconsistent. A carriage return is checked by looking ahead for the line
+// feed that must follow it.
+func (r *Reader) checkEOL(r1 rune) error {
+	var eol string
+	switch {
+	case r1 == '\r':
+		next, err := r.r.Peek(1)
+		if err != nil || next[0] != '\n' {
+			return r.wrap(ErrInconsistentLineEnding)
+		}
+		eol = "\r\n"
+	case r.crlf:
+		// the line feed of a \r\n pair, already checked
+		return nil
+	default:
+		eol = "\n"
+	}
+	if r.eol == "" {
+		r.eol = eol
+	} else if r.eol != eol {
+		return r.wrap(ErrInconsistentLineEnding)
+	}
+	return nil
+}
+
+// endLine completes a line terminator ending in r1 by reading the line feed of a \r\n pair, so that the
+// terminator belongs in full to the line it ends.
+func (r *Reader) endLine(r1 rune) error {
+	if r1 != '\r' {
+		return nil
+	}
+	if next, err := r.r.Peek(1); err != nil || next[0] != '\n' {
+		return nil
+	}
+	_, err := r.readRune()
+	return err
+}
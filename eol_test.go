@@ -0,0 +1,109 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestLineEndings(t *testing.T) {
+	const stmt = "<http://example/s> <http://example/p> <http://example/o> ."
+
+	testCases := []struct {
+		name   string
+		input  string
+		strict bool
+		count  int
+		line   int // the line of the expected error, if any
+		column int
+	}{
+		{name: "lf", input: stmt + "\n" + stmt + "\n", count: 2},
+		{name: "crlf", input: stmt + "\r\n" + stmt + "\r\n", count: 2},
+		{name: "cr", input: stmt + "\r" + stmt + "\r", count: 2},
+		{name: "mixed", input: stmt + "\r\n" + stmt + "\n" + stmt + "\r", count: 3},
+		{name: "blank lines", input: "\r\n\r\n\r" + stmt + "\n", count: 1},
+		{name: "comment crlf", input: "# comment\r\n" + stmt + " # comment\r\n" + stmt, count: 2},
+		{name: "error after crlf", input: stmt + "\r\n\r\nbad", count: 1, line: 3, column: 0},
+		{name: "error after cr", input: stmt + "\r\rbad", count: 1, line: 3, column: 0},
+		{name: "error after lf cr", input: stmt + "\n\r  bad", count: 1, line: 3, column: 2},
+		{name: "strict lf", input: stmt + "\n" + stmt + "\n", strict: true, count: 2},
+		{name: "strict crlf", input: "# comment\r\n" + stmt + "\r\n\r\n" + stmt + "\r\n", strict: true, count: 2},
+		{name: "strict cr", input: stmt + "\r" + stmt + "\r", strict: true, line: 1, column: 58},
+		{name: "strict cr at end", input: stmt + "\r\n" + stmt + "\r", strict: true, count: 1, line: 2, column: 58},
+		{name: "strict mixed", input: stmt + "\r\n" + stmt + "\n", strict: true, count: 1, line: 2, column: 58},
+		{name: "strict mixed comment", input: "# comment\n" + stmt + " # comment\r\n", strict: true, line: 2, column: 68},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []Option
+			if tc.strict {
+				opts = append(opts, WithStrictLineEndings())
+			}
+			r := NewReader(strings.NewReader(tc.input), opts...)
+			count := 0
+			for r.Next() {
+				count++
+			}
+			if count != tc.count {
+				t.Errorf("got %d quads, wanted %d", count, tc.count)
+			}
+			if tc.line == 0 {
+				if r.Err() != nil {
+					t.Errorf("got unexpected error %q", r.Err())
+				}
+				return
+			}
+			var perr *ParseError
+			if !errors.As(r.Err(), &perr) {
+				t.Fatalf("got error %v, wanted a ParseError", r.Err())
+			}
+			if tc.strict && !errors.Is(perr, ErrInconsistentLineEnding) {
+				t.Errorf("got error %v, wanted %v", perr.Err, ErrInconsistentLineEnding)
+			}
+			if perr.Line != tc.line || perr.Column != tc.column {
+				t.Errorf("got error at line %d column %d, wanted line %d column %d", perr.Line, perr.Column, tc.line, tc.column)
+			}
+		})
+	}
+}
+
+func TestLineEndingsInLiterals(t *testing.T) {
+	for _, eol := range []string{"\n", "\r\n", "\r"} {
+		input := "<http://example/s> <http://example/p> \"a" + eol + "b\" .\n<http://example/s> <http://example/p> \"c\" .\n"
+		var skipped int
+		r := NewReader(strings.NewReader(input), WithSkipInvalid(func([]byte, error) { skipped++ }))
+		var perr *ParseError
+		var got []Quad
+		for r.Next() {
+			got = append(got, r.Quad())
+		}
+		if r.Err() != nil {
+			t.Errorf("%q: got unexpected error %q", eol, r.Err())
+			continue
+		}
+		// the line break ends the statement, leaving the remainder of the literal on the next line
+		if skipped != 2 {
+			t.Errorf("%q: got %d statements skipped, wanted 2", eol, skipped)
+		}
+		if len(got) != 1 || got[0].O != rdf.Literal("c") {
+			t.Errorf("%q: got quads %v, wanted only the last", eol, got)
+		}
+
+		r = NewReader(strings.NewReader(input))
+		for r.Next() {
+		}
+		if !errors.As(r.Err(), &perr) || !errors.Is(perr, ErrUnexpectedEOF) {
+			t.Errorf("%q: got error %v, wanted %v", eol, r.Err(), ErrUnexpectedEOF)
+		} else if perr.Line != 1 {
+			t.Errorf("%q: got error on line %d, wanted line 1", eol, perr.Line)
+		}
+	}
+}
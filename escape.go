@@ -0,0 +1,86 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+
+	"github.com/iand/gordf"
+)
+
+// EscapedLiteralTerm is the kind of term that represents a literal whose Value still holds the escape
+// sequences it was written with, as returned by a Reader configured using WithEscapedLiterals. It extends
+// the kinds of term defined by the rdf package. The Language and Datatype of the term are those of the
+// literal. Writers and AppendTerm write the value of such a term as it is, without escaping it again.
+const EscapedLiteralTerm = QuotedTripleTerm + 1
+
+// WithEscapedLiterals configures the Reader to return the values of literals as they appear in the input,
+// with any escape sequences left in place, rather than decoding them. Escapes are still checked for syntax
+// errors. Applications that only route, filter or count quads avoid the cost of decoding values they never
+// examine, and can decode the values they need using UnescapeTerm or UnescapeLiteral.
+//
+// Literals whose values contain escapes are returned as terms of kind EscapedLiteralTerm rather than
+// rdf.LiteralTerm, so that they are not mistaken for decoded values. Other literals are returned as usual.
+// Literals within quoted triples are always decoded. Checks and transformations applied to literal values
+// by other options, such as WithSubjects, see the escaped form, except that WithDedup compares the decoded
+// values.
+func WithEscapedLiterals() Option {
+	return func(r *Reader) {
+		r.escapedLiterals = true
+	}
+}
+
+// UnescapeTerm returns t with its value decoded if it is of kind EscapedLiteralTerm, as a term of kind
+// rdf.LiteralTerm. Other terms are returned unchanged. It returns an error if the value of t is not valid as
+// the body of a quoted literal.
+func UnescapeTerm(t rdf.Term) (rdf.Term, error) {
+	if t.Kind != EscapedLiteralTerm {
+		return t, nil
+	}
+	value, err := UnescapeLiteral(t.Value)
+	if err != nil {
+		return t, err
+	}
+	t.Value = value
+	t.Kind = rdf.LiteralTerm
+	return t, nil
+}
+
+// unescapedQuad returns q with an escaped literal object decoded as described by UnescapeTerm, so that it
+// compares equal to the same quad read without WithEscapedLiterals. The object is left unchanged if it
+// cannot be decoded.
+func unescapedQuad(q Quad) Quad {
+	if q.O.Kind == EscapedLiteralTerm {
+		if o, err := UnescapeTerm(q.O); err == nil {
+			q.O = o
+		}
+	}
+	return q
+}
+
+// isLiteral reports whether t is a literal, escaped or not.
+func isLiteral(t rdf.Term) bool {
+	return t.Kind == rdf.LiteralTerm || t.Kind == EscapedLiteralTerm
+}
+
+// UnescapeLiteral decodes the escape sequences in the value of a literal read by a Reader configured using
+// WithEscapedLiterals. A value containing no escapes is returned unchanged, without allocating. It returns
+// an error if value is not valid as the body of a quoted literal.
+func UnescapeLiteral(value string) (string, error) {
+	if strings.IndexByte(value, '\\') < 0 {
+		return value, nil
+	}
+	r := newStatementReader([]byte(value + "\" "))
+	term, err := r.parseLiteral()
+	if err != nil {
+		return "", err
+	}
+	if r.r.i != len(value)+1 {
+		// the value contains an unescaped quote
+		return "", r.wrap(ErrUnexpectedCharacter)
+	}
+	return term.Value, nil
+}
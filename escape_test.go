@@ -0,0 +1,148 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestEscapedLiterals(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  rdf.Term
+		value string
+	}{
+		{
+			input: `<http://example/s> <http://example/p> "plain" .`,
+			want:  rdf.Literal("plain"),
+			value: "plain",
+		},
+		{
+			input: `<http://example/s> <http://example/p> "tab\there \"quoted\" é"@fr .`,
+			want:  rdf.Term{Value: `tab\there \"quoted\" é`, Language: "fr", Kind: EscapedLiteralTerm},
+			value: "tab\there \"quoted\" é",
+		},
+		{
+			input: `<http://example/s> <http://example/p> "\\\U0001F600"^^<http://example/dt> .`,
+			want:  rdf.Term{Value: `\\\U0001F600`, Datatype: "http://example/dt", Kind: EscapedLiteralTerm},
+			value: "\\\U0001F600",
+		},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(tc.input), WithEscapedLiterals())
+		if !r.Next() {
+			t.Fatalf("%s: got unexpected error %q", tc.input, r.Err())
+		}
+		if got := r.Quad().O; got != tc.want {
+			t.Errorf("%s: got %v, wanted %v", tc.input, got, tc.want)
+		}
+		value, err := UnescapeLiteral(r.Quad().O.Value)
+		if err != nil {
+			t.Errorf("%s: got unexpected error %q", tc.input, err)
+		} else if value != tc.value {
+			t.Errorf("%s: got unescaped value %q, wanted %q", tc.input, value, tc.value)
+		}
+		term, err := UnescapeTerm(r.Quad().O)
+		if err != nil {
+			t.Errorf("%s: got unexpected error %q", tc.input, err)
+		} else if want := (rdf.Term{Value: tc.value, Language: tc.want.Language, Datatype: tc.want.Datatype, Kind: rdf.LiteralTerm}); term != want {
+			t.Errorf("%s: got unescaped term %v, wanted %v", tc.input, term, want)
+		}
+	}
+
+	// escapes are still checked
+	r := NewReader(strings.NewReader(`<http://example/s> <http://example/p> "bad \q escape" .`), WithEscapedLiterals())
+	if r.Next() || r.Err() == nil {
+		t.Errorf("got no error for an invalid escape")
+	}
+}
+
+func TestUnescapeLiteralInvalid(t *testing.T) {
+	for _, value := range []string{`bad \q escape`, `\u00`, `unescaped " quote \n`, `trailing \`} {
+		if got, err := UnescapeLiteral(value); err == nil {
+			t.Errorf("%s: got %q, wanted an error", value, got)
+		}
+	}
+}
+
+func TestEscapedLiteralsRoundTrip(t *testing.T) {
+	input := "<http://example/s> <http://example/p> \"a\\tb\" .\n" +
+		"<http://example/s> <http://example/p> \"say \\\"hi\\\"\"@en .\n" +
+		"<http://example/s> <http://example/p> \"caf\\u00E9 \\U0001F600\"^^<http://example/dt> <http://example/g> .\n" +
+		"<< <http://example/s> <http://example/p> \"x\\ty\" >> <http://example/p> \"plain\" .\n"
+
+	var buf strings.Builder
+	w := NewWriter(&buf)
+	r := NewReader(strings.NewReader(input), WithEscapedLiterals())
+	for r.Next() {
+		if err := w.Write(r.Quad()); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	w.Flush()
+
+	want := strings.Replace(input, "\\ty", "\ty", 1)
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), want)
+	}
+
+	// Copy writes the values through unchanged when the Writer transforms its output
+	buf.Reset()
+	w = NewWriter(&buf)
+	w.UseCRLF = true
+	if _, err := Copy(w, NewReader(strings.NewReader(input), WithEscapedLiterals())); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	w.Flush()
+	if got := strings.ReplaceAll(buf.String(), "\r\n", "\n"); got != want {
+		t.Errorf("got from Copy:\n%s\nwanted:\n%s", got, want)
+	}
+
+	// the output decodes to the same quads as the input
+	got, err := ReadAll(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	decoded, err := ReadAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if !slices.Equal(got, decoded) {
+		t.Errorf("got %v, wanted %v", got, decoded)
+	}
+}
+
+func TestEscapedLiteralsCompareDecoded(t *testing.T) {
+	input := "<http://example/s> <http://example/p> \"a\\tb\" .\n" +
+		"<http://example/s> <http://example/p> \"a\\u0009b\" .\n" +
+		"<http://example/s> <http://example/p> \"a\tb\" .\n"
+
+	n := 0
+	r := NewReader(strings.NewReader(input), WithEscapedLiterals(), WithDedup(10))
+	for r.Next() {
+		n++
+	}
+	if r.Err() != nil || n != 1 {
+		t.Errorf("got %d quads and error %v with dedup, wanted 1 quad", n, r.Err())
+	}
+
+	d, err := LoadDataset(strings.NewReader(input), WithEscapedLiterals())
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	want := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal("a\tb")}
+	if got := d.Quads(); !slices.Equal(got, []Quad{want}) {
+		t.Errorf("got dataset quads %v, wanted %v", got, []Quad{want})
+	}
+}
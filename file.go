@@ -0,0 +1,65 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"compress/gzip"
+	"os"
+)
+
+// A ReadCloser is a Reader that owns its source, such as a file opened by OpenFile, which must be closed
+// once all quads have been read.
+type ReadCloser struct {
+	*Reader
+	closers []func() error // called in order by Close
+}
+
+// OpenFile opens the named file and returns a ReadCloser that reads quads from it, configured using the
+// supplied options. Files with names ending in .gz, as reported by CompressionFor, are decompressed as they
+// are read.
+//
+// Where the platform supports it, an uncompressed file is memory mapped and parsed as by NewBytesReader,
+// avoiding copying the file into a buffer. The strings of terms may then refer to the mapping, so quads read
+// from the ReadCloser must not be used after Close unless their strings have been copied, for example using
+// strings.Clone. A file that cannot be mapped, such as a pipe, is read as by NewReader.
+func OpenFile(name string, opts ...Option) (*ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	rc := &ReadCloser{closers: []func() error{f.Close}}
+
+	if CompressionFor(name) == Gzip {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		rc.Reader = NewReader(zr, opts...)
+		rc.closers = append([]func() error{zr.Close}, rc.closers...)
+		return rc, nil
+	}
+
+	if b, err := mmapFile(f); err == nil {
+		rc.Reader = newMemoryReader(b, true, opts...)
+		rc.closers = append([]func() error{func() error { return munmap(b) }}, rc.closers...)
+		return rc, nil
+	}
+	rc.Reader = NewReader(f, opts...)
+	return rc, nil
+}
+
+// Close releases any mapping of the file and closes it. It returns the first error encountered.
+func (rc *ReadCloser) Close() error {
+	var err error
+	for _, c := range rc.closers {
+		if cerr := c(); err == nil {
+			err = cerr
+		}
+	}
+	rc.closers = nil
+	return err
+}
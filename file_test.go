@@ -0,0 +1,86 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenFile(t *testing.T) {
+	want, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	dir := t.TempDir()
+	testCases := []struct {
+		name  string
+		quads []Quad
+	}{
+		{name: "dump.nq", quads: want},
+		{name: "dump.nq.gz", quads: want},
+		{name: "empty.nq", quads: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name)
+			w, err := CreateFile(path)
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			for _, q := range tc.quads {
+				if err := w.Write(q); err != nil {
+					t.Fatalf("got unexpected error %q", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+
+			r, err := OpenFile(path)
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			var got []Quad
+			for r.Next() {
+				got = append(got, r.Quad())
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if len(got) != len(tc.quads) {
+				t.Fatalf("got %d quads, wanted %d", len(got), len(tc.quads))
+			}
+			for i := range got {
+				if got[i] != tc.quads[i] {
+					t.Errorf("got %s, wanted %s", got[i], tc.quads[i])
+				}
+			}
+			if err := r.Close(); err != nil {
+				t.Errorf("got unexpected error %q", err)
+			}
+		})
+	}
+}
+
+func TestOpenFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenFile(filepath.Join(dir, "missing.nq")); !os.IsNotExist(err) {
+		t.Errorf("got error %v, wanted a missing file error", err)
+	}
+
+	path := filepath.Join(dir, "bad.nq.gz")
+	if err := os.WriteFile(path, []byte("<http://example/s> <http://example/p> \"o\" .\n"), 0o644); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if _, err := OpenFile(path); err == nil {
+		t.Errorf("got no error opening a file that is not gzip compressed")
+	}
+}
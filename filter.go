@@ -0,0 +1,85 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"github.com/iand/gordf"
+)
+
+// WithGraphFilter configures the Reader to return only the quads for which fn returns true when called with
+// the graph term of the quad. The graph term is of unknown kind for quads in the default graph. Quads that
+// are rejected are still checked for syntax errors but are otherwise skipped by Next.
+func WithGraphFilter(fn func(g rdf.Term) bool) Option {
+	return func(r *Reader) {
+		r.graphFilter = fn
+	}
+}
+
+// WithGraphs configures the Reader to return only the quads in the named graphs with the given IRIs. The
+// empty string selects the default graph.
+func WithGraphs(iris ...string) Option {
+	graphs := stringSet(iris)
+	return WithGraphFilter(func(g rdf.Term) bool {
+		switch g.Kind {
+		case rdf.UnknownTerm:
+			return graphs[""]
+		case rdf.IRITerm:
+			return graphs[g.Value]
+		default:
+			return false
+		}
+	})
+}
+
+// WithSubjects configures the Reader to return only the quads whose subject is one of the given IRIs.
+//
+// The subject of each statement is compared as soon as it has been read and the remainder of the line
+// holding a statement that does not match is discarded without being parsed, making this much faster than
+// filtering the quads returned by Next when only a small part of a large input is wanted. As a consequence,
+// syntax errors following the subject of a discarded statement are not reported.
+func WithSubjects(iris ...string) Option {
+	return func(r *Reader) {
+		r.subjects = stringSet(iris)
+	}
+}
+
+// WithPredicates configures the Reader to return only the quads whose predicate is one of the given IRIs,
+// such as only the rdfs:label statements of a dataset. Like WithSubjects, statements that do not match are
+// discarded without parsing their objects or graphs.
+func WithPredicates(iris ...string) Option {
+	return func(r *Reader) {
+		r.predicates = stringSet(iris)
+	}
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// accept reports whether the quad just read passes the filters configured for r.
+func (r *Reader) accept() bool {
+	if r.filtered {
+		r.filtered = false
+		return false
+	}
+	if r.graphFilter != nil && !r.graphFilter(r.q.G) {
+		return false
+	}
+	return r.dedup == nil || r.dedup.add(*r.q)
+}
+
+// reject discards the remainder of the current statement after it has been rejected by a filter. It always
+// returns true so that reading continues with the next statement.
+func (r *Reader) reject() bool {
+	r.filtered = true
+	n, _ := r.r.Discard(r.r.Buffered())
+	r.offset += int64(n)
+	return true
+}
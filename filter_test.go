@@ -0,0 +1,165 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+const filterInput = "<http://example/s> <http://example/p> \"default\" .\n" +
+	"<http://example/s> <http://example/p> \"g1\" <http://example/g1> .\n" +
+	"<http://example/s> <http://example/p> \"g2\" <http://example/g2> .\n" +
+	"<http://example/s> <http://example/p> \"blank\" _:g .\n" +
+	"<http://example/s> <http://example/p> \"g1 again\" <http://example/g1> .\n"
+
+func readObjects(t *testing.T, input string, opts ...Option) []string {
+	t.Helper()
+	r := NewReader(strings.NewReader(input), opts...)
+	var got []string
+	for r.Next() {
+		got = append(got, r.Quad().O.Value)
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	return got
+}
+
+func TestGraphFilter(t *testing.T) {
+	testCases := []struct {
+		name string
+		opt  Option
+		want []string
+	}{
+		{
+			name: "named graphs",
+			opt:  WithGraphFilter(func(g rdf.Term) bool { return g.Kind != rdf.UnknownTerm }),
+			want: []string{"g1", "g2", "blank", "g1 again"},
+		},
+		{
+			name: "blank graphs",
+			opt:  WithGraphFilter(func(g rdf.Term) bool { return g.Kind == rdf.BlankTerm }),
+			want: []string{"blank"},
+		},
+		{
+			name: "graph iri",
+			opt:  WithGraphs("http://example/g1"),
+			want: []string{"g1", "g1 again"},
+		},
+		{
+			name: "default and graph iri",
+			opt:  WithGraphs("", "http://example/g2"),
+			want: []string{"default", "g2"},
+		},
+		{
+			name: "none",
+			opt:  WithGraphs(),
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := readObjects(t, filterInput, tc.opt)
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGraphFilterSyntaxError(t *testing.T) {
+	r := NewReader(strings.NewReader(filterInput+"<http://example/s> <http://example/p> bad <http://example/g3> .\n"), WithGraphs("http://example/g1"))
+	n := 0
+	for r.Next() {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("got %d quads, wanted 2", n)
+	}
+	if r.Err() == nil {
+		t.Errorf("got no error for a syntax error in a rejected statement")
+	}
+}
+
+func TestSubjectPredicateFilters(t *testing.T) {
+	input := "<http://example/a> <http://www.w3.org/2000/01/rdf-schema#label> \"A\" .\n" +
+		"<http://example/a> <http://example/p> \"skipped\" . # comment\n" +
+		"_:b <http://www.w3.org/2000/01/rdf-schema#label> \"B\" .\r\n" +
+		"\n" +
+		"<http://example/c> <http://www.w3.org/2000/01/rdf-schema#label> \"C\" <http://example/g> .\n" +
+		"<http://example/e> <http://example/p> this is not parsed\n" +
+		"<http://example/\\u0061> <http://www.w3.org/2000/01/rdf-schema#label> \"escaped\" .\n" +
+		"<http://example/d> <http://example/p> \"" + strings.Repeat("x", 10000) + "\" .\n" +
+		"<http://example/a> <http://example/p> \"last\" ."
+
+	testCases := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "subjects",
+			opts: []Option{WithSubjects("http://example/a", "http://example/c")},
+			want: []string{"A", "skipped", "C", "escaped", "last"},
+		},
+		{
+			name: "predicates",
+			opts: []Option{WithPredicates("http://www.w3.org/2000/01/rdf-schema#label")},
+			want: []string{"A", "B", "C", "escaped"},
+		},
+		{
+			name: "subjects and predicates",
+			opts: []Option{WithSubjects("http://example/c"), WithPredicates("http://www.w3.org/2000/01/rdf-schema#label")},
+			want: []string{"C"},
+		},
+		{
+			name: "subjects and graphs",
+			opts: []Option{WithSubjects("http://example/a", "http://example/c"), WithGraphs("")},
+			want: []string{"A", "skipped", "escaped", "last"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReaderSize(strings.NewReader(input), 16, tc.opts...)
+			var got []string
+			for r.Next() {
+				got = append(got, r.Quad().O.Value)
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubjectFilterPosition(t *testing.T) {
+	input := "<http://example/a> <http://example/p> \"1\" .\n" +
+		"<http://example/b> <http://example/p> \"2\" .\n" +
+		"<http://example/a> <http://example/p> bad .\n"
+	r := NewReader(strings.NewReader(input), WithSubjects("http://example/a"))
+	if !r.Next() {
+		t.Fatalf("got no quad, error %v", r.Err())
+	}
+	if r.Next() {
+		t.Fatalf("got unexpected quad %v", r.Quad())
+	}
+	var perr *ParseError
+	if !errors.As(r.Err(), &perr) {
+		t.Fatalf("got error %v, wanted a ParseError", r.Err())
+	}
+	if perr.Line != 3 || perr.Column != 38 {
+		t.Errorf("got error at line %d column %d, wanted line 3 column 38", perr.Line, perr.Column)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"iter"
+
+	"github.com/iand/gordf"
+)
+
+// termIndexes locate the quads of a Dataset by their terms. Each index is keyed by a pair of terms, in the
+// order given by its name, and holds the positions in Dataset.quads of the quads with those terms, in
+// ascending order. The remaining terms of a pattern are matched by checking each quad found.
+type termIndexes struct {
+	spog   map[rdf.Term]*termPositions // by subject, then predicate
+	posg   map[rdf.Term]*termPositions // by predicate, then object
+	ospg   map[rdf.Term]*termPositions // by object, then subject
+	graphs map[rdf.Term][]int          // by graph, including the default graph
+	names  []rdf.Term                  // the names of the graphs other than the default, in order of appearance
+}
+
+// termPositions holds the positions of the quads with a term, in total and by a second term.
+type termPositions struct {
+	all []int
+	by  map[rdf.Term][]int
+}
+
+func newTermIndexes() *termIndexes {
+	return &termIndexes{
+		spog:   make(map[rdf.Term]*termPositions),
+		posg:   make(map[rdf.Term]*termPositions),
+		ospg:   make(map[rdf.Term]*termPositions),
+		graphs: make(map[rdf.Term][]int),
+	}
+}
+
+// add records that q is held at position i.
+func (x *termIndexes) add(q Quad, i int) {
+	addPosition(x.spog, q.S, q.P, i)
+	addPosition(x.posg, q.P, q.O, i)
+	addPosition(x.ospg, q.O, q.S, i)
+	if _, ok := x.graphs[q.G]; !ok && q.G != (rdf.Term{}) {
+		x.names = append(x.names, q.G)
+	}
+	x.graphs[q.G] = append(x.graphs[q.G], i)
+}
+
+func addPosition(index map[rdf.Term]*termPositions, a, b rdf.Term, i int) {
+	tp := index[a]
+	if tp == nil {
+		tp = &termPositions{by: make(map[rdf.Term][]int)}
+		index[a] = tp
+	}
+	tp.all = append(tp.all, i)
+	tp.by[b] = append(tp.by[b], i)
+}
+
+// lookup returns the positions of the quads that may match the given subject, predicate, object and graph,
+// any of which may be nil, and reports whether every quad may match because none is given.
+func (x *termIndexes) lookup(s, p, o, g *rdf.Term) (positions []int, all bool) {
+	switch {
+	case s != nil && p != nil:
+		return x.spog[*s].get(p), false
+	case s != nil && o != nil:
+		return x.ospg[*o].get(s), false
+	case p != nil && o != nil:
+		return x.posg[*p].get(o), false
+	case s != nil:
+		return x.spog[*s].get(nil), false
+	case p != nil:
+		return x.posg[*p].get(nil), false
+	case o != nil:
+		return x.ospg[*o].get(nil), false
+	case g != nil:
+		return x.graphs[*g], false
+	}
+	return nil, true
+}
+
+// get returns the positions of the quads that also have term t, or all the positions if t is nil.
+func (tp *termPositions) get(t *rdf.Term) []int {
+	if tp == nil {
+		return nil
+	}
+	if t == nil {
+		return tp.all
+	}
+	return tp.by[*t]
+}
+
+// buildTermIndexes ensures that d.terms indexes every quad in d.quads. The caller must hold the write lock.
+func (d *Dataset) buildTermIndexes() {
+	if d.terms != nil {
+		return
+	}
+	d.terms = newTermIndexes()
+	for i, q := range d.quads {
+		d.terms.add(q, i)
+	}
+}
+
+// Find returns an iterator over the quads in the dataset that match the given subject, predicate, object
+// and graph, in the order determined by d.Order. A nil term matches any term; a pointer to a zero rdf.Term
+// for the graph matches quads in the default graph. Lookups use indexes of the terms of the quads
+// that are built when Find is first called and maintained as quads are added.
+//
+// The quads yielded are those present when iteration begins. The dataset may be modified while iterating.
+func (d *Dataset) Find(s, p, o, g *rdf.Term) iter.Seq[Quad] {
+	return func(yield func(Quad) bool) {
+		quads, positions, all := d.candidates(s, p, o, g)
+		match := func(q Quad) bool {
+			return (s == nil || q.S == *s) && (p == nil || q.P == *p) && (o == nil || q.O == *o) && (g == nil || q.G == *g)
+		}
+
+		var found []Quad
+		emit := func(q Quad) bool {
+			if !match(q) {
+				return true
+			}
+			if d.Order == TermOrder {
+				found = append(found, q)
+				return true
+			}
+			return yield(q)
+		}
+		if all {
+			for _, q := range quads {
+				if !emit(q) {
+					return
+				}
+			}
+		} else {
+			for _, i := range positions {
+				if !emit(quads[i]) {
+					return
+				}
+			}
+		}
+
+		sortQuads(found)
+		for _, q := range found {
+			if !yield(q) {
+				return
+			}
+		}
+	}
+}
+
+// candidates returns the quads of the dataset and the positions of those that may match a pattern, as
+// described by termIndexes.lookup. The slices returned are not modified by later additions to the dataset.
+func (d *Dataset) candidates(s, p, o, g *rdf.Term) (quads []Quad, positions []int, all bool) {
+	d.mu.RLock()
+	if d.terms != nil || (s == nil && p == nil && o == nil && g == nil) {
+		positions, all = d.lookup(s, p, o, g)
+		quads = d.quads
+		d.mu.RUnlock()
+		return quads, positions, all
+	}
+	d.mu.RUnlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buildTermIndexes()
+	positions, all = d.lookup(s, p, o, g)
+	return d.quads, positions, all
+}
+
+// lookup is like termIndexes.lookup but does not require the indexes to have been built if no term is
+// given. The caller must hold the lock.
+func (d *Dataset) lookup(s, p, o, g *rdf.Term) ([]int, bool) {
+	if s == nil && p == nil && o == nil && g == nil {
+		return nil, true
+	}
+	return d.terms.lookup(s, p, o, g)
+}
+
+// indexes returns the term indexes of the dataset, building them if needed, with the read lock held. The
+// caller must release the read lock.
+func (d *Dataset) indexes() *termIndexes {
+	d.mu.RLock()
+	if d.terms != nil {
+		return d.terms
+	}
+	d.mu.RUnlock()
+
+	d.mu.Lock()
+	d.buildTermIndexes()
+	d.mu.Unlock()
+	d.mu.RLock()
+	return d.terms
+}
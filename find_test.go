@@ -0,0 +1,99 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestDatasetFind(t *testing.T) {
+	s1 := rdf.IRI("http://example/s1")
+	s2 := rdf.IRI("http://example/s2")
+	p := rdf.IRI("http://example/p")
+	o := rdf.IRI("http://example/o")
+	g := rdf.IRI("http://example/g")
+	z := rdf.Literal("z")
+	missing := rdf.IRI("http://example/missing")
+	def := rdf.Term{}
+
+	testCases := []struct {
+		name       string
+		s, p, o, g *rdf.Term
+		want       []int // indexes of the quads of datasetInput, in insertion order
+	}{
+		{name: "all", want: []int{0, 1, 2, 3, 4, 5, 6}},
+		{name: "subject", s: &s1, want: []int{2, 3, 4, 5, 6}},
+		{name: "predicate", p: &p, want: []int{0, 1, 2, 3, 4, 5, 6}},
+		{name: "object", o: &o, want: []int{1, 5}},
+		{name: "graph", g: &g, want: []int{1, 2}},
+		{name: "default graph", g: &def, want: []int{0, 3, 4, 5, 6}},
+		{name: "subject and predicate", s: &s2, p: &p, want: []int{0}},
+		{name: "subject and object", s: &s1, o: &z, want: []int{2, 6}},
+		{name: "predicate and object", p: &p, o: &o, want: []int{1, 5}},
+		{name: "all terms", s: &s1, p: &p, o: &z, g: &g, want: []int{2}},
+		{name: "object and graph", o: &z, g: &def, want: []int{6}},
+		{name: "missing subject", s: &missing},
+		{name: "missing predicate", s: &s1, p: &missing},
+	}
+
+	d := loadTestDataset(t, datasetInput)
+	all := slices.Collect(d.Find(nil, nil, nil, nil))
+	if len(all) != 7 {
+		t.Fatalf("got %d quads, wanted 7", len(all))
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var want []Quad
+			for _, i := range tc.want {
+				want = append(want, all[i])
+			}
+			got := slices.Collect(d.Find(tc.s, tc.p, tc.o, tc.g))
+			if !slices.Equal(got, want) {
+				t.Errorf("got %v, wanted %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDatasetFindTermOrder(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	d.Order = TermOrder
+	s1 := rdf.IRI("http://example/s1")
+
+	got := slices.Collect(d.Find(&s1, nil, nil, nil))
+	want := slices.DeleteFunc(d.Quads(), func(q Quad) bool { return q.S != s1 })
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestDatasetFindAfterAdd(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	s3 := rdf.IRI("http://example/s3")
+	p := rdf.IRI("http://example/p")
+	if n := len(slices.Collect(d.Find(&s3, nil, nil, nil))); n != 0 {
+		t.Fatalf("got %d quads, wanted none", n)
+	}
+
+	q := Quad{S: s3, P: p, O: rdf.Literal("c")}
+	d.Add(q)
+	if got := slices.Collect(d.Find(nil, &p, &q.O, nil)); !slices.Equal(got, []Quad{q}) {
+		t.Errorf("got %v, wanted %v", got, []Quad{q})
+	}
+
+	snap := d.Snapshot()
+	d.Add(Quad{S: s3, P: p, O: rdf.Literal("d")})
+	if n := len(slices.Collect(snap.Find(&s3, nil, nil, nil))); n != 1 {
+		t.Errorf("got %d quads from snapshot, wanted 1", n)
+	}
+	if n := len(slices.Collect(d.Find(&s3, nil, nil, nil))); n != 2 {
+		t.Errorf("got %d quads, wanted 2", n)
+	}
+}
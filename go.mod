@@ -1,5 +1,5 @@
 module github.com/iand/nquads
 
-go 1.21.4
+go 1.23
 
 require github.com/iand/gordf v0.1.8
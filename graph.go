@@ -0,0 +1,60 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/iand/gordf"
+)
+
+// DefaultGraph is the name of the default graph, for use with methods of Dataset that accept the name of
+// a graph. It is the zero rdf.Term, which is the graph term of quads in the default graph.
+var DefaultGraph = rdf.Term{}
+
+// A Triple is an RDF triple made up of gordf terms, as held in a single graph of a dataset.
+type Triple struct {
+	S rdf.Term
+	P rdf.Term
+	O rdf.Term
+}
+
+func (t Triple) String() string {
+	return fmt.Sprintf("%s %s %s .", t.S.String(), t.P.String(), t.O.String())
+}
+
+// Graph returns the triples of the graph with the given name, which may be DefaultGraph, in the order
+// determined by d.Order. It returns an empty slice if the dataset holds no quads in the graph.
+func (d *Dataset) Graph(name rdf.Term) []Triple {
+	triples := []Triple{}
+	for q := range d.Find(nil, nil, nil, &name) {
+		triples = append(triples, Triple{S: q.S, P: q.P, O: q.O})
+	}
+	return triples
+}
+
+// GraphNames returns the names of the named graphs that hold quads in the dataset. The default graph is not
+// included. If d.Order is InsertionOrder the names are returned in the order in which they first appeared,
+// otherwise they are sorted as described by CompareTerms.
+func (d *Dataset) GraphNames() []rdf.Term {
+	x := d.indexes()
+	names := slices.Clone(x.names)
+	d.mu.RUnlock()
+
+	if d.Order == TermOrder {
+		slices.SortFunc(names, CompareTerms)
+	}
+	return names
+}
+
+// IsEmptyGraph reports whether the dataset holds no quads in the graph with the given name. The zero
+// rdf.Term, DefaultGraph, names the default graph.
+func (d *Dataset) IsEmptyGraph(name rdf.Term) bool {
+	x := d.indexes()
+	defer d.mu.RUnlock()
+	return len(x.graphs[name]) == 0
+}
@@ -0,0 +1,141 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestDatasetGraphNames(t *testing.T) {
+	input := datasetInput + "<http://example/s> <http://example/p> <http://example/o> _:g .\n" +
+		"<http://example/s> <http://example/p> <http://example/o> <http://example/a> .\n"
+
+	testCases := []struct {
+		name  string
+		input string
+		order Order
+		want  []rdf.Term
+	}{
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name:  "default graph only",
+			input: "<http://example/s> <http://example/p> <http://example/o> .\n",
+		},
+		{
+			name:  "insertion order",
+			input: input,
+			want:  []rdf.Term{rdf.IRI("http://example/g"), rdf.Blank("g"), rdf.IRI("http://example/a")},
+		},
+		{
+			name:  "term order",
+			input: input,
+			order: TermOrder,
+			want:  []rdf.Term{rdf.IRI("http://example/a"), rdf.IRI("http://example/g"), rdf.Blank("g")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := loadTestDataset(t, tc.input)
+			d.Order = tc.order
+			if got := d.GraphNames(); !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDatasetIsEmptyGraph(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+
+	testCases := []struct {
+		name string
+		g    rdf.Term
+		want bool
+	}{
+		{name: "default", g: rdf.Term{}, want: false},
+		{name: "named", g: rdf.IRI("http://example/g"), want: false},
+		{name: "missing", g: rdf.IRI("http://example/missing"), want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := d.IsEmptyGraph(tc.g); got != tc.want {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+
+	g := rdf.IRI("http://example/new")
+	d.Add(Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal("x"), G: g})
+	if d.IsEmptyGraph(g) {
+		t.Errorf("graph is empty after adding a quad to it")
+	}
+	if names := d.GraphNames(); !slices.Contains(names, g) {
+		t.Errorf("got names %v, wanted them to include %s", names, g.String())
+	}
+}
+
+func TestDatasetGraph(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	p := rdf.IRI("http://example/p")
+
+	testCases := []struct {
+		name  string
+		g     rdf.Term
+		order Order
+		want  []Triple
+	}{
+		{
+			name: "named",
+			g:    rdf.IRI("http://example/g"),
+			want: []Triple{
+				{S: rdf.Blank("b1"), P: p, O: rdf.IRI("http://example/o")},
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.Literal("z")},
+			},
+		},
+		{
+			name:  "named in term order",
+			g:     rdf.IRI("http://example/g"),
+			order: TermOrder,
+			want: []Triple{
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.Literal("z")},
+				{S: rdf.Blank("b1"), P: p, O: rdf.IRI("http://example/o")},
+			},
+		},
+		{
+			name: "default",
+			g:    DefaultGraph,
+			want: []Triple{
+				{S: rdf.IRI("http://example/s2"), P: p, O: rdf.Literal("b")},
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.LiteralWithLanguage("a", "en")},
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.Literal("a")},
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.IRI("http://example/o")},
+				{S: rdf.IRI("http://example/s1"), P: p, O: rdf.Literal("z")},
+			},
+		},
+		{
+			name: "missing",
+			g:    rdf.IRI("http://example/missing"),
+			want: []Triple{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d.Order = tc.order
+			if got := d.Graph(tc.g); !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
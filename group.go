@@ -0,0 +1,73 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/iand/gordf"
+)
+
+// errStopGroups is returned by the function passed to groupBySubject by SubjectGroups to stop iteration.
+var errStopGroups = errors.New("stop")
+
+// SubjectGroups returns an iterator over the runs of consecutive quads read by r that share a subject,
+// yielding each subject with its quads. When the input is sorted or grouped by subject, as produced by a
+// Writer from a Dataset in TermOrder, each subject is yielded once with all of its quads, so entities can be
+// processed one at a time without holding the whole input in memory.
+//
+// The slice of quads is reused for the next group, so it must be copied if it is retained. Err should be
+// checked once iteration is complete. If iteration is stopped early, the first quad of the next group is
+// returned by the next call to Next, so the remaining quads can still be read from r.
+//
+//	for s, quads := range r.SubjectGroups() {
+//		...
+//	}
+//	if err := r.Err(); err != nil {
+//		return err
+//	}
+func (r *Reader) SubjectGroups() iter.Seq2[rdf.Term, []Quad] {
+	return func(yield func(rdf.Term, []Quad) bool) {
+		groupBySubject(r, false, func(s rdf.Term, quads []Quad) error {
+			if !yield(s, quads) {
+				return errStopGroups
+			}
+			return nil
+		})
+	}
+}
+
+// groupBySubject reads quads from r and calls fn with each run of consecutive quads that share a subject.
+// The slice passed to fn is reused for the next group. Iteration stops at the first error returned by fn.
+// If reading stops because of an error, the run that was interrupted is passed to fn only if partial is
+// true.
+func groupBySubject(r *Reader, partial bool, fn func(s rdf.Term, quads []Quad) error) error {
+	var group []Quad
+	for r.Next() {
+		q := r.Quad()
+		if len(group) > 0 && group[0].S != q.S {
+			if err := fn(group[0].S, group); err != nil {
+				if err == errStopGroups {
+					r.held = &q
+				}
+				return err
+			}
+			group = group[:0]
+		}
+		group = append(group, q)
+	}
+	if r.Err() != nil {
+		if partial && len(group) > 0 {
+			fn(group[0].S, group)
+		}
+		return r.Err()
+	}
+	if len(group) > 0 {
+		return fn(group[0].S, group)
+	}
+	return nil
+}
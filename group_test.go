@@ -0,0 +1,122 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestSubjectGroups(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  []string // the subject of each group
+		sizes []int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "grouped",
+			input: "<http://example/a> <http://example/p> \"1\" .\n<http://example/a> <http://example/p> \"2\" .\n_:b <http://example/p> \"3\" .\n<http://example/c> <http://example/p> \"4\" <http://example/g> .\n",
+			want:  []string{"<http://example/a>", "_:b", "<http://example/c>"},
+			sizes: []int{2, 1, 1},
+		},
+		{
+			name:  "ungrouped",
+			input: "<http://example/a> <http://example/p> \"1\" .\n_:b <http://example/p> \"2\" .\n<http://example/a> <http://example/p> \"3\" .\n",
+			want:  []string{"<http://example/a>", "_:b", "<http://example/a>"},
+			sizes: []int{1, 1, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.input))
+			var got []string
+			var sizes []int
+			for s, quads := range r.SubjectGroups() {
+				got = append(got, s.String())
+				sizes = append(sizes, len(quads))
+				for _, q := range quads {
+					if q.S != s {
+						t.Errorf("got quad %s in group of %s", q, s.String())
+					}
+				}
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if !slices.Equal(got, tc.want) || !slices.Equal(sizes, tc.sizes) {
+				t.Errorf("got subjects %v with sizes %v, wanted %v with sizes %v", got, sizes, tc.want, tc.sizes)
+			}
+		})
+	}
+}
+
+func TestSubjectGroupsStop(t *testing.T) {
+	input := "<http://example/a> <http://example/p> \"1\" .\n<http://example/b> <http://example/p> \"2\" .\n<http://example/c> <http://example/p> \"3\" .\n"
+	r := NewReader(strings.NewReader(input))
+	var got []rdf.Term
+	for s := range r.SubjectGroups() {
+		got = append(got, s)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if want := []rdf.Term{rdf.IRI("http://example/a"), rdf.IRI("http://example/b")}; !slices.Equal(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	// The quads of the next group are still read after stopping
+	var rest []rdf.Term
+	for r.Next() {
+		rest = append(rest, r.Quad().S)
+	}
+	if want := []rdf.Term{rdf.IRI("http://example/c")}; !slices.Equal(rest, want) {
+		t.Errorf("got %v after stopping, wanted %v", rest, want)
+	}
+}
+
+func TestSubjectGroupsResume(t *testing.T) {
+	input := "<http://example/a> <http://example/p> \"1\" .\n<http://example/b> <http://example/p> \"2\" .\n<http://example/b> <http://example/p> \"3\" .\n"
+	r := NewReader(strings.NewReader(input))
+	for range r.SubjectGroups() {
+		break
+	}
+
+	var sizes []int
+	for _, quads := range r.SubjectGroups() {
+		sizes = append(sizes, len(quads))
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if want := []int{2}; !slices.Equal(sizes, want) {
+		t.Errorf("got group sizes %v after resuming, wanted %v", sizes, want)
+	}
+}
+
+func TestSubjectGroupsError(t *testing.T) {
+	r := NewReader(strings.NewReader("<http://example/a> <http://example/p> \"1\" .\nbad\n"))
+	n := 0
+	for range r.SubjectGroups() {
+		n++
+	}
+	if r.Err() == nil {
+		t.Errorf("got no error for invalid input")
+	}
+	if n != 0 {
+		t.Errorf("got %d groups, wanted none before the error", n)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"github.com/iand/gordf"
+)
+
+// A GroupedWriter buffers quads in memory and writes them using a Writer grouped by graph, so that all the
+// statements in a graph are written together. Graphs are written in the order in which they were first seen
+// and the statements within each graph keep the order in which they were written.
+//
+// If Comments is true, each group is preceded by a comment naming the graph, such as
+// "# graph: <http://example/g>", or "# default graph" for the default graph. The Comments field can be
+// changed before the first call to Flush.
+//
+// Quads are only written when Flush is called, so Flush should normally be called once after all quads have
+// been written.
+type GroupedWriter struct {
+	Comments bool // true to write a comment naming the graph before each group
+
+	w      *Writer
+	graphs []rdf.Term
+	groups map[rdf.Term][]Quad
+}
+
+// NewGroupedWriter returns a new GroupedWriter that writes to w.
+func NewGroupedWriter(w *Writer) *GroupedWriter {
+	return &GroupedWriter{
+		w:      w,
+		groups: make(map[rdf.Term][]Quad),
+	}
+}
+
+// Write adds q to the quads buffered for the next call to Flush. It never returns an error.
+func (g *GroupedWriter) Write(q Quad) error {
+	group, ok := g.groups[q.G]
+	if !ok {
+		g.graphs = append(g.graphs, q.G)
+	}
+	g.groups[q.G] = append(group, q)
+	return nil
+}
+
+// Flush writes the buffered quads to the underlying Writer, grouped by graph, and flushes it. To check if
+// an error occurred during the Flush, call Error.
+func (g *GroupedWriter) Flush() {
+	defer g.w.Flush()
+graphs:
+	for _, graph := range g.graphs {
+		if g.Comments {
+			var comment string
+			if graph.Kind == rdf.UnknownTerm {
+				comment = "default graph"
+			} else {
+				comment = "graph: " + termText(graph)
+			}
+			if err := g.w.WriteComment(comment); err != nil {
+				break graphs
+			}
+		}
+		for _, q := range g.groups[graph] {
+			if err := g.w.Write(q); err != nil {
+				break graphs
+			}
+		}
+	}
+	g.graphs = g.graphs[:0]
+	g.groups = make(map[rdf.Term][]Quad)
+}
+
+// Error reports any error that has occurred during a previous Flush.
+func (g *GroupedWriter) Error() error {
+	return g.w.Error()
+}
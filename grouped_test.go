@@ -0,0 +1,86 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGroupedWriter(t *testing.T) {
+	input := `<http://example/s> <http://example/p> "1" <http://example/g1> .
+<http://example/s> <http://example/p> "2" .
+<http://example/s> <http://example/p> "3" _:g2 .
+<http://example/s> <http://example/p> "4" <http://example/g1> .
+<http://example/s> <http://example/p> "5" .
+`
+
+	testCases := []struct {
+		name     string
+		comments bool
+		want     string
+	}{
+		{
+			name: "plain",
+			want: `<http://example/s> <http://example/p> "1" <http://example/g1> .
+<http://example/s> <http://example/p> "4" <http://example/g1> .
+<http://example/s> <http://example/p> "2" .
+<http://example/s> <http://example/p> "5" .
+<http://example/s> <http://example/p> "3" _:g2 .
+`,
+		},
+		{
+			name:     "comments",
+			comments: true,
+			want: `# graph: <http://example/g1>
+<http://example/s> <http://example/p> "1" <http://example/g1> .
+<http://example/s> <http://example/p> "4" <http://example/g1> .
+# default graph
+<http://example/s> <http://example/p> "2" .
+<http://example/s> <http://example/p> "5" .
+# graph: _:g2
+<http://example/s> <http://example/p> "3" _:g2 .
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewGroupedWriter(NewWriter(&buf))
+			w.Comments = tc.comments
+			r := NewReader(strings.NewReader(input))
+			for r.Next() {
+				if err := w.Write(r.Quad()); err != nil {
+					t.Fatalf("got unexpected error %q", err)
+				}
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			w.Flush()
+			if err := w.Error(); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+
+			got := buf.String()
+			if got != tc.want {
+				t.Errorf("got:\n%s\nwanted:\n%s", got, tc.want)
+			}
+
+			// Output must remain parseable
+			n := 0
+			r = NewReader(strings.NewReader(got))
+			for r.Next() {
+				n++
+			}
+			if r.Err() != nil || n != 5 {
+				t.Errorf("got %d quads and error %v when parsing output, wanted 5", n, r.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"container/list"
+)
+
+// defaultInternSize is the number of distinct strings held by the interner used by WithInterning.
+const defaultInternSize = 4096
+
+// WithInterning configures the Reader to share a single string between all occurrences of the same IRI,
+// datatype IRI or language tag, instead of allocating a new string for each. Predicates, datatypes, graph
+// names and language tags typically take few distinct values, so this greatly reduces the allocations and
+// memory needed to hold the quads of a large input. The most recently used strings are remembered, up to a
+// fixed limit, so inputs with many distinct IRIs do not exhaust memory.
+func WithInterning() Option {
+	return func(r *Reader) {
+		r.interner = newInterner(defaultInternSize)
+	}
+}
+
+// internedText is like text but returns a shared string if r is configured to intern strings.
+func (r *Reader) internedText() string {
+	if r.interner == nil || r.discard {
+		return r.text()
+	}
+	return r.interner.intern(r.buf.Bytes())
+}
+
+// maxVocabStrings is the number of distinct predicate and datatype IRIs remembered by a Reader that is not
+// configured using WithInterning.
+const maxVocabStrings = 256
+
+// vocabSpan returns the text of a predicate or datatype IRI as described by internedSpan. If r is not
+// configured to intern strings, the IRI is looked up in a small cache of the predicates and datatypes
+// already read, since an input typically uses few of them, so each distinct IRI is allocated once and
+// shared by all the quads that use it. The cache stops growing once full. Readers whose terms share
+// memory with the input need no cache.
+func (r *Reader) vocabSpan(start, end int) string {
+	if r.interner != nil || r.shared || r.discard {
+		return r.internedSpan(start, end)
+	}
+	if s, ok := r.vocab[string(r.buf.Bytes())]; ok {
+		return s
+	}
+	if len(r.vocab) >= maxVocabStrings {
+		return r.spanText(start, end)
+	}
+	if r.vocab == nil {
+		r.vocab = make(map[string]string)
+	}
+	s := r.buf.String()
+	r.vocab[s] = s
+	return s
+}
+
+// An interner returns a shared string for each distinct byte sequence, remembering at most max strings and
+// discarding the least recently used when full.
+type interner struct {
+	max     int
+	strings map[string]*list.Element
+	lru     list.List // of string, most recently used at the front
+}
+
+func newInterner(max int) *interner {
+	return &interner{
+		max:     max,
+		strings: make(map[string]*list.Element, max),
+	}
+}
+
+// intern returns the shared string equal to b, adding one if there is none.
+func (in *interner) intern(b []byte) string {
+	if el, ok := in.strings[string(b)]; ok {
+		in.lru.MoveToFront(el)
+		return el.Value.(string)
+	}
+	if len(in.strings) >= in.max {
+		oldest := in.lru.Back()
+		in.lru.Remove(oldest)
+		delete(in.strings, oldest.Value.(string))
+	}
+	s := string(b)
+	in.strings[s] = in.lru.PushFront(s)
+	return s
+}
@@ -0,0 +1,112 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func sameString(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestInterning(t *testing.T) {
+	input := strings.Repeat("<http://example/s> <http://example/p> \"x\"@en <http://example/g> .\n"+
+		"<http://example/s> <http://example/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n", 3)
+
+	for _, interning := range []bool{false, true} {
+		var opts []Option
+		if interning {
+			opts = append(opts, WithInterning())
+		}
+		quads, err := ReadAll(strings.NewReader(input), opts...)
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		first, second := quads[0], quads[1]
+		for _, q := range quads[2:] {
+			other := first
+			if q.O.Datatype != "" {
+				other = second
+			}
+			if q != other {
+				t.Fatalf("got quad %v, wanted %v", q, other)
+			}
+			shared := sameString(q.P.Value, first.P.Value) && sameString(q.S.Value, first.S.Value)
+			if q.O.Datatype != "" {
+				shared = shared && sameString(q.O.Datatype, second.O.Datatype)
+			} else {
+				shared = shared && sameString(q.O.Language, first.O.Language) && sameString(q.G.Value, first.G.Value)
+			}
+			if shared != interning {
+				t.Errorf("interning %v: got shared strings %v", interning, shared)
+			}
+		}
+	}
+}
+
+func TestVocabCache(t *testing.T) {
+	input := "<http://example/s1> <http://example/p> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n" +
+		"<http://example/s2> <http://example/\\u0070> \"2\"^^<http://www.w3.org/2001/XMLSchema#integer> .\n" +
+		"<http://example/p> <http://example/p> <http://example/p> .\n"
+	quads, err := ReadAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	for _, q := range quads[1:] {
+		if !sameString(q.P.Value, quads[0].P.Value) {
+			t.Errorf("got predicate %q not shared with the first", q.P.Value)
+		}
+	}
+	if !sameString(quads[1].O.Datatype, quads[0].O.Datatype) {
+		t.Errorf("got datatype %q not shared with the first", quads[1].O.Datatype)
+	}
+	if sameString(quads[2].S.Value, quads[0].P.Value) || sameString(quads[2].O.Value, quads[0].P.Value) {
+		t.Errorf("got subject or object shared with the predicate")
+	}
+
+	// the cache stops growing once full
+	var b strings.Builder
+	for i := 0; i < maxVocabStrings+10; i++ {
+		fmt.Fprintf(&b, "<http://example/s> <http://example/p%d> \"%d\" .\n", i, i)
+	}
+	r := NewReader(strings.NewReader(b.String()))
+	n := 0
+	for r.Next() {
+		if want := fmt.Sprintf("http://example/p%d", n); r.Quad().P.Value != want {
+			t.Errorf("got predicate %q, wanted %q", r.Quad().P.Value, want)
+		}
+		n++
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(r.vocab) != maxVocabStrings {
+		t.Errorf("got %d cached strings, wanted %d", len(r.vocab), maxVocabStrings)
+	}
+}
+
+func TestInternerEviction(t *testing.T) {
+	in := newInterner(2)
+	a := in.intern([]byte("a"))
+	in.intern([]byte("b"))
+	if !sameString(in.intern([]byte("a")), a) {
+		t.Errorf("a was not shared")
+	}
+	in.intern([]byte("c")) // evicts b, the least recently used
+	if len(in.strings) != 2 {
+		t.Errorf("got %d strings, wanted 2", len(in.strings))
+	}
+	if _, ok := in.strings["b"]; ok {
+		t.Errorf("b was not evicted")
+	}
+	if !sameString(in.intern([]byte("a")), a) {
+		t.Errorf("a was evicted")
+	}
+}
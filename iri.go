@@ -0,0 +1,206 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidIRI is the error returned when an IRI does not conform to the grammar of RFC 3987 and the Reader
+// has been configured using WithStrictIRIs.
+var ErrInvalidIRI = errors.New("invalid IRI")
+
+// WithStrictIRIs configures the Reader to check that every IRI conforms to the IRI grammar of RFC 3987,
+// after any escapes have been decoded, failing with ErrInvalidIRI if not. By default the Reader only checks
+// that an IRI contains a colon, which accepts many invalid IRIs. IRIs resolved against a base IRI are checked
+// after resolution and relative IRIs accepted using WithRelativeIRIs are checked against the grammar for
+// relative references.
+func WithStrictIRIs() Option {
+	return func(r *Reader) {
+		r.strictIRIs = true
+	}
+}
+
+// isValidIRI reports whether s is a valid IRI according to RFC 3987, or a valid relative reference if
+// relative is true.
+func isValidIRI(s string, relative bool) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		if !validIRIChars(s[i+1:], "/?", false) {
+			return false
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		if !validIRIChars(s[i+1:], "/?", true) {
+			return false
+		}
+		s = s[:i]
+	}
+
+	if !relative {
+		i := strings.IndexByte(s, ':')
+		if i < 0 || !isValidScheme(s[:i]) {
+			return false
+		}
+		s = s[i+1:]
+	} else if i := strings.IndexAny(s, ":/"); i >= 0 && s[i] == ':' {
+		// The first segment of a relative path may not contain a colon
+		return false
+	}
+
+	if strings.HasPrefix(s, "//") {
+		s = s[2:]
+		i := strings.IndexByte(s, '/')
+		if i < 0 {
+			i = len(s)
+		}
+		if !isValidAuthority(s[:i]) {
+			return false
+		}
+		s = s[i:]
+	}
+	return validIRIChars(s, "/", false)
+}
+
+// isValidScheme reports whether s matches the scheme production of RFC 3986.
+func isValidScheme(s string) bool {
+	if s == "" || !isAlpha(rune(s[0])) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isAlpha(rune(c)) && !isNumeral(rune(c)) && c != '+' && c != '-' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidAuthority reports whether s matches the iauthority production of RFC 3987.
+func isValidAuthority(s string) bool {
+	if i := strings.LastIndexByte(s, '@'); i >= 0 {
+		if !validIRIChars(s[:i], ":", false) {
+			return false
+		}
+		s = s[i+1:]
+	}
+
+	host := s
+	if strings.HasPrefix(s, "[") {
+		end := strings.IndexByte(s, ']')
+		if end < 0 || !isValidIPLiteral(s[1:end]) {
+			return false
+		}
+		host, s = "", s[end+1:]
+	} else if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		host, s = s[:i], s[i:]
+	} else {
+		s = ""
+	}
+	if !validIRIChars(host, "", false) {
+		return false
+	}
+
+	// What remains is an optional port
+	if s != "" {
+		if s[0] != ':' {
+			return false
+		}
+		for i := 1; i < len(s); i++ {
+			if !isNumeral(rune(s[i])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isValidIPLiteral reports whether s is an IPv6 address or an IPvFuture address as permitted between the
+// brackets of an IP-literal.
+func isValidIPLiteral(s string) bool {
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		dot := strings.IndexByte(s, '.')
+		if dot < 2 || dot == len(s)-1 {
+			return false
+		}
+		for i := 1; i < dot; i++ {
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+		for i := dot + 1; i < len(s); i++ {
+			if c := s[i]; !isUnreserved(c) && !isSubDelim(c) && c != ':' {
+				return false
+			}
+		}
+		return true
+	}
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6() && addr.Zone() == ""
+}
+
+// validIRIChars reports whether s consists only of iunreserved characters, percent encodings, sub-delims,
+// ':', '@', the characters in extra and, if private is true, characters from the iprivate ranges.
+func validIRIChars(s string, extra string, private bool) bool {
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c == '%' {
+			if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return false
+			}
+			i += 3
+			continue
+		}
+		if c < utf8.RuneSelf {
+			if !isUnreserved(c) && !isSubDelim(c) && c != ':' && c != '@' && strings.IndexByte(extra, c) < 0 {
+				return false
+			}
+			i++
+			continue
+		}
+		r1, size := utf8.DecodeRuneInString(s[i:])
+		if !isUcsChar(r1) && !(private && isPrivateChar(r1)) {
+			return false
+		}
+		i += size
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return isNumeral(rune(c)) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isUnreserved(c byte) bool {
+	return isAlpha(rune(c)) || isNumeral(rune(c)) || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isSubDelim(c byte) bool {
+	return strings.IndexByte("!$&'()*+,;=", c) >= 0
+}
+
+// isUcsChar reports whether r is in the ucschar production of RFC 3987.
+func isUcsChar(r rune) bool {
+	switch {
+	case r >= 0xA0 && r <= 0xD7FF, r >= 0xF900 && r <= 0xFDCF, r >= 0xFDF0 && r <= 0xFFEF:
+		return true
+	case r >= 0x10000 && r <= 0xEFFFD:
+		// Each plane excludes its last two code points
+		return r&0xFFFF <= 0xFFFD
+	}
+	return false
+}
+
+// isPrivateChar reports whether r is in the iprivate production of RFC 3987.
+func isPrivateChar(r rune) bool {
+	return (r >= 0xE000 && r <= 0xF8FF) || (r >= 0xF0000 && r <= 0xFFFFD) || (r >= 0x100000 && r <= 0x10FFFD)
+}
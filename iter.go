@@ -0,0 +1,39 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the quads read by r. If an error is encountered the iterator yields it with
+// a zero Quad and then stops, so it is not necessary to call Err afterwards.
+//
+//	for q, err := range r.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (r *Reader) All() iter.Seq2[Quad, error] {
+	return func(yield func(Quad, error) bool) {
+		for r.Next() {
+			if !yield(r.Quad(), nil) {
+				return
+			}
+		}
+		if err := r.Err(); err != nil {
+			yield(Quad{}, err)
+		}
+	}
+}
+
+// Quads returns an iterator over the quads read from src by a Reader configured using opts, as described by
+// Reader.All.
+func Quads(src io.Reader, opts ...Option) iter.Seq2[Quad, error] {
+	return NewReader(src, opts...).All()
+}
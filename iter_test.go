@@ -0,0 +1,58 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReaderAll(t *testing.T) {
+	var got []Quad
+	for q, err := range NewReader(strings.NewReader(datasetInput)).All() {
+		if err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		got = append(got, q)
+	}
+	if len(got) != 8 {
+		t.Errorf("got %d quads, wanted 8", len(got))
+	}
+}
+
+func TestQuadsError(t *testing.T) {
+	input := "<http://example/s> <http://example/p> <http://example/o> .\n<relative> <http://example/p> <http://example/o> .\n<http://example/s> <http://example/p> <http://example/o> .\n"
+
+	n := 0
+	var gotErr error
+	for _, err := range Quads(strings.NewReader(input)) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		n++
+	}
+	if n != 1 {
+		t.Errorf("got %d quads, wanted 1", n)
+	}
+	if !errors.Is(gotErr, ErrRelativeIRI) {
+		t.Errorf("got error %v, wanted %v", gotErr, ErrRelativeIRI)
+	}
+}
+
+func TestQuadsBreak(t *testing.T) {
+	n := 0
+	for range Quads(strings.NewReader(datasetInput)) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("got %d quads, wanted 2", n)
+	}
+}
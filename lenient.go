@@ -0,0 +1,89 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+)
+
+// WithSkipInvalid configures the Reader to skip any statement containing a syntax error and continue reading
+// from the following line, instead of stopping. If fn is not nil it is called with the source bytes of the
+// line holding each skipped statement, without its line terminator, and the error. The line is only valid
+// for the duration of the call. When fn is not nil the Reader records the source bytes of each statement as
+// if configured using WithRawCapture.
+//
+// Errors from the underlying reader are not skipped and stop the Reader as usual.
+func WithSkipInvalid(fn func(line []byte, err error)) Option {
+	return func(r *Reader) {
+		r.skipInvalid = true
+		r.onInvalid = fn
+		if fn != nil {
+			r.capture = true
+		}
+	}
+}
+
+// WithTruncatedInput configures the Reader to accept a final statement that is cut short by the end of the
+// input, as happens when a download is interrupted. The statement is accepted if its terms are complete and
+// only the terminating '.' is missing. A statement that ends part way through a term is still an error. A
+// missing line terminator after the final statement is always accepted.
+func WithTruncatedInput() Option {
+	return func(r *Reader) {
+		r.truncated = true
+	}
+}
+
+// WithErrorCollection configures the Reader to skip any statement containing a syntax error, as described by
+// WithSkipInvalid, and record the error so that every problem in the input can be reported after a single
+// pass. The recorded errors are returned by Errors. If limit is positive, at most limit errors are recorded
+// and the Reader stops at the next syntax error, which is then returned by Err.
+func WithErrorCollection(limit int) Option {
+	return func(r *Reader) {
+		r.skipInvalid = true
+		r.collect = true
+		r.maxErrors = limit
+	}
+}
+
+// Errors returns the syntax errors recorded for statements that were skipped, in the order they were
+// encountered. It returns nil unless the Reader was configured using WithErrorCollection.
+func (r *Reader) Errors() []error {
+	return append([]error(nil), r.errs...)
+}
+
+// skip discards the remainder of the line holding a statement that could not be parsed because of a syntax
+// error, reporting it to r.onInvalid. It returns true if reading can continue.
+func (r *Reader) skip() bool {
+	var perr *ParseError
+	if !errors.As(r.err, &perr) {
+		return false
+	}
+	if r.collect && r.maxErrors > 0 && len(r.errs) >= r.maxErrors {
+		return false
+	}
+	err := r.err
+	r.err = nil
+
+	// The statement cannot span lines, so the remainder of its line is discarded
+	rest, _ := r.r.Peek(r.r.Buffered())
+	if r.capture {
+		r.raw = append(r.raw, rest...)
+	}
+	r.r.Discard(len(rest))
+	r.offset += int64(len(rest))
+
+	r.skipped++
+	if r.collect {
+		r.errs = append(r.errs, err)
+	}
+	if r.onInvalid != nil {
+		line := bytes.TrimSuffix(r.raw, []byte{'\n'})
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		r.onInvalid(line, err)
+	}
+	return true
+}
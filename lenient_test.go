@@ -0,0 +1,179 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestSkipInvalid(t *testing.T) {
+	input := "<http://example/s> <http://example/p> \"1\" .\n" +
+		"<http://example/s> <http://example/p> \"2\"\n" + // error found at the line terminator
+		"<http://example/s> <http://example/p> \"3\" .\r\n" +
+		"<http://example/s> bad \"4\" . # error found mid-line\n" +
+		"# comment\n" +
+		"<http://example/s> <http://example/p> \"5\" .\n" +
+		"<relative> <http://example/p> \"6\" .\n" +
+		"<http://example/s> <http://example/p> \"7\"" // error found at the end of input
+
+	type skipped struct {
+		line string
+		err  error
+	}
+	var got []skipped
+	r := NewReader(strings.NewReader(input), WithSkipInvalid(func(line []byte, err error) {
+		got = append(got, skipped{line: string(line), err: err})
+	}))
+
+	var values []string
+	for r.Next() {
+		values = append(values, r.Quad().O.Value)
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+
+	if want := "1 3 5"; strings.Join(values, " ") != want {
+		t.Errorf("got values %q, wanted %q", strings.Join(values, " "), want)
+	}
+
+	want := []skipped{
+		{line: `<http://example/s> <http://example/p> "2"`, err: ErrUnexpectedCharacter},
+		{line: `<http://example/s> bad "4" . # error found mid-line`, err: ErrUnexpectedCharacter},
+		{line: `<relative> <http://example/p> "6" .`, err: ErrRelativeIRI},
+		{line: `<http://example/s> <http://example/p> "7"`, err: ErrUnexpectedEOF},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d skipped lines, wanted %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].line != want[i].line {
+			t.Errorf("%d: got line %q, wanted %q", i, got[i].line, want[i].line)
+		}
+		if !errors.Is(got[i].err, want[i].err) {
+			t.Errorf("%d: got error %v, wanted %v", i, got[i].err, want[i].err)
+		}
+	}
+}
+
+func TestSkipInvalidNoCallback(t *testing.T) {
+	input := "bad\n<http://example/s> <http://example/p> \"1\" .\n"
+	r := NewReader(strings.NewReader(input), WithSkipInvalid(nil))
+
+	n := 0
+	for r.Next() {
+		n++
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if n != 1 {
+		t.Errorf("got %d quads, wanted 1", n)
+	}
+}
+
+func TestErrorCollection(t *testing.T) {
+	input := "bad 1\n<http://example/s> <http://example/p> \"1\" .\nbad 2\nbad 3\n<http://example/s> <http://example/p> \"2\" .\n"
+
+	testCases := []struct {
+		name      string
+		limit     int
+		quads     int
+		errors    int
+		stopError bool
+	}{
+		{name: "unlimited", limit: 0, quads: 2, errors: 3},
+		{name: "limit-not-reached", limit: 3, quads: 2, errors: 3},
+		{name: "limit-reached", limit: 2, quads: 1, errors: 2, stopError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(input), WithErrorCollection(tc.limit))
+			n := 0
+			for r.Next() {
+				n++
+			}
+			if n != tc.quads {
+				t.Errorf("got %d quads, wanted %d", n, tc.quads)
+			}
+			if got := len(r.Errors()); got != tc.errors {
+				t.Errorf("got %d errors, wanted %d", got, tc.errors)
+			}
+			if (r.Err() != nil) != tc.stopError {
+				t.Errorf("got error %v, wanted error: %v", r.Err(), tc.stopError)
+			}
+
+			for i, err := range r.Errors() {
+				var perr *ParseError
+				if !errors.As(err, &perr) {
+					t.Errorf("%d: got error %v, wanted a ParseError", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncatedInput(t *testing.T) {
+	first := "<http://example/s> <http://example/p> \"first\" .\n"
+	testCases := []struct {
+		input string
+		want  rdf.Term
+		err   error
+	}{
+		{input: `<http://example/s> <http://example/p> <http://example/o> .`, want: rdf.IRI("http://example/o")},
+		{input: `<http://example/s> <http://example/p> <http://example/o>`, want: rdf.IRI("http://example/o")},
+		{input: "<http://example/s> <http://example/p> <http://example/o> \t", want: rdf.IRI("http://example/o")},
+		{input: `<http://example/s> <http://example/p> "x"`, want: rdf.Literal("x")},
+		{input: `<http://example/s> <http://example/p> "x"@en-GB`, want: rdf.LiteralWithLanguage("x", "en-GB")},
+		{input: `<http://example/s> <http://example/p> "x"^^<http://example/dt>`, want: rdf.LiteralWithDatatype("x", "http://example/dt")},
+		{input: `<http://example/s> <http://example/p> _:b`, want: rdf.Blank("b")},
+		{input: `<http://example/s> <http://example/p> <http://example/o> <http://example/g>`, want: rdf.IRI("http://example/o")},
+		{input: `<http://example/s> <http://example/p> <http://example/o> _:g`, want: rdf.IRI("http://example/o")},
+		{input: `<http://example/s> <http://example/p> "x`, err: ErrUnexpectedEOF},
+		{input: `<http://example/s> <http://example/p> "x"@`, err: ErrUnexpectedEOF},
+		{input: `<http://example/s> <http://example/p> <http://example/o`, err: ErrUnexpectedEOF},
+		{input: `<http://example/s> <http://example/p>`, err: ErrUnexpectedEOF},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(first+tc.input), WithTruncatedInput())
+		var got []Quad
+		for r.Next() {
+			got = append(got, r.Quad())
+		}
+		if tc.err != nil {
+			if !errors.Is(r.Err(), tc.err) {
+				t.Errorf("%s: got error %v, wanted %v", tc.input, r.Err(), tc.err)
+			}
+			continue
+		}
+		if r.Err() != nil {
+			t.Errorf("%s: got unexpected error %q", tc.input, r.Err())
+			continue
+		}
+		if len(got) != 2 {
+			t.Errorf("%s: got %d quads, wanted 2", tc.input, len(got))
+			continue
+		}
+		if got[1].O != tc.want {
+			t.Errorf("%s: got object %v, wanted %v", tc.input, got[1].O, tc.want)
+		}
+	}
+}
+
+func TestTruncatedInputStrict(t *testing.T) {
+	r := NewReader(strings.NewReader(`<http://example/s> <http://example/p> <http://example/o>`))
+	for r.Next() {
+	}
+	if !errors.Is(r.Err(), ErrUnterminatedQuad) {
+		t.Errorf("got error %v, wanted %v", r.Err(), ErrUnterminatedQuad)
+	}
+}
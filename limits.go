@@ -0,0 +1,129 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+)
+
+// These are the errors returned in ParseError.Err when a limit configured on a Reader is exceeded.
+var (
+	// ErrStatementTooLong is the error returned when a statement is longer than the limit set by
+	// WithMaxStatementLength.
+	ErrStatementTooLong = errors.New("statement too long")
+
+	// ErrLiteralTooLong is the error returned when a literal value is longer than the limit set by
+	// WithMaxLiteralLength.
+	ErrLiteralTooLong = errors.New("literal too long")
+
+	// ErrIRITooLong is the error returned when an IRI is longer than the limit set by WithMaxIRILength.
+	ErrIRITooLong = errors.New("IRI too long")
+)
+
+// WithMaxStatementLength configures the Reader to fail with ErrStatementTooLong if a line holding a statement
+// is longer than n bytes, excluding its line terminator. This bounds the memory used when reading untrusted
+// input.
+//
+// If WithMaxLiteralLength or WithMaxIRILength is used without WithMaxStatementLength, lines are limited to
+// the length needed to hold a statement whose literal and IRIs are within those limits even if every
+// character is written using the longest escape, plus 64KB for whitespace, blank node labels and comments.
+// The memory used is then bounded too, and ErrStatementTooLong is returned for longer lines.
+func WithMaxStatementLength(n int) Option {
+	return func(r *Reader) {
+		r.maxStatement = n
+	}
+}
+
+// WithMaxLiteralLength configures the Reader to fail with ErrLiteralTooLong if the value of a literal is
+// longer than n bytes after any escapes have been decoded.
+func WithMaxLiteralLength(n int) Option {
+	return func(r *Reader) {
+		r.maxLiteral = n
+	}
+}
+
+// WithMaxIRILength configures the Reader to fail with ErrIRITooLong if an IRI, including the datatype IRI of
+// a literal, is longer than n bytes after any escapes have been decoded.
+func WithMaxIRILength(n int) Option {
+	return func(r *Reader) {
+		r.maxIRI = n
+	}
+}
+
+// The constants used by lineLimit.
+const (
+	maxEscapeLen   = 10       // the length of the longest escape, \UXXXXXXXX, which may encode a single byte
+	statementIRIs  = 5        // the IRIs of a statement: subject, predicate, object, graph and datatype
+	lineLimitSlack = 64 << 10 // the length allowed for whitespace, blank node labels and comments
+)
+
+// lineLimit returns the maximum length of a line read by r, or zero if lines are unlimited. It is the limit
+// set by WithMaxStatementLength or, if only the lengths of literals and IRIs are limited, the longest line
+// that can hold a statement within those limits.
+func (r *Reader) lineLimit() int {
+	if r.maxStatement > 0 || (r.maxLiteral <= 0 && r.maxIRI <= 0) {
+		return r.maxStatement
+	}
+	n := lineLimitSlack
+	for _, term := range [...]struct{ max, count int }{{r.maxLiteral, 1}, {r.maxIRI, statementIRIs}} {
+		if term.max <= 0 {
+			continue
+		}
+		if term.max > (math.MaxInt-n)/(maxEscapeLen*term.count) {
+			return 0
+		}
+		n += term.max * maxEscapeLen * term.count
+	}
+	return n
+}
+
+// WithMaxQuads configures the Reader to stop after n quads have been returned by Next, as though the end of
+// the input had been reached. LimitReached reports whether any quads remained unread. This is useful for
+// previewing the start of a large input and for bounding the work done on untrusted input.
+func WithMaxQuads(n int) Option {
+	return func(r *Reader) {
+		r.maxQuads = n
+	}
+}
+
+// LimitReached reports whether Next stopped returning quads because of the limit set by WithMaxQuads while
+// more statements remained in the input. The statements that remain are not parsed, so they may not be
+// valid or may be rejected by a filter.
+func (r *Reader) LimitReached() bool {
+	return r.limitReached
+}
+
+// stopAtLimit is called by Next once the limit on the number of quads has been reached. It looks ahead to
+// find whether another statement follows, without parsing it, and always returns false.
+func (r *Reader) stopAtLimit() bool {
+	*r.q = Quad{}
+	if !r.limitReached && r.err == nil {
+		r.limitReached, r.err = r.moreStatements()
+	}
+	return false
+}
+
+// moreStatements reports whether any line that is not blank or a comment remains in the input, discarding
+// the lines before it.
+func (r *Reader) moreStatements() (bool, error) {
+	line := r.r.b[r.r.i:]
+	for {
+		if line = bytes.TrimLeft(line, " \t\r\n"); len(line) > 0 && line[0] != '#' {
+			return true, nil
+		}
+		var err error
+		if line, err = r.lines.scan(); err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, err
+		}
+		r.r.reset(nil)
+	}
+}
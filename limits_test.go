@@ -0,0 +1,164 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	statement := `<http://example/s> <http://example/p> "value"^^<http://example/dt> .`
+
+	testCases := []struct {
+		name string
+		opt  Option
+		err  error
+	}{
+		{name: "statement-fits", opt: WithMaxStatementLength(len(statement))},
+		{name: "statement-too-long", opt: WithMaxStatementLength(len(statement) - 1), err: ErrStatementTooLong},
+		{name: "literal-fits", opt: WithMaxLiteralLength(5)},
+		{name: "literal-too-long", opt: WithMaxLiteralLength(4), err: ErrLiteralTooLong},
+		{name: "iri-fits", opt: WithMaxIRILength(len("http://example/dt"))},
+		{name: "iri-too-long", opt: WithMaxIRILength(len("http://example/s") - 1), err: ErrIRITooLong},
+		{name: "datatype-too-long", opt: WithMaxIRILength(len("http://example/dt") - 1), err: ErrIRITooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Two statements check that the statement length is measured per line
+			r := NewReader(strings.NewReader(statement+"\n"+statement+"\n"), tc.opt)
+			n := 0
+			for r.Next() {
+				n++
+			}
+			if tc.err == nil {
+				if r.Err() != nil || n != 2 {
+					t.Errorf("got %d quads and error %v, wanted 2 quads", n, r.Err())
+				}
+				return
+			}
+			if !errors.Is(r.Err(), tc.err) {
+				t.Errorf("got error %v, wanted %v", r.Err(), tc.err)
+			}
+		})
+	}
+}
+
+// endlessReader returns an endless stream of the same byte.
+type endlessReader byte
+
+func (e endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(e)
+	}
+	return len(p), nil
+}
+
+func TestLimitUnterminatedLiteral(t *testing.T) {
+	src := io.MultiReader(strings.NewReader(`<http://example/s> <http://example/p> "`), endlessReader('a'))
+	r := NewReader(src, WithMaxStatementLength(1<<16))
+	if r.Next() {
+		t.Fatalf("got quad, wanted error")
+	}
+	if !errors.Is(r.Err(), ErrStatementTooLong) {
+		t.Errorf("got error %v, wanted %v", r.Err(), ErrStatementTooLong)
+	}
+}
+
+func TestLimitUnterminatedLiteralMemory(t *testing.T) {
+	testCases := []struct {
+		name   string
+		prefix string
+		opt    Option
+		want   error
+	}{
+		{name: "literal", prefix: `<http://example/s> <http://example/p> "`, opt: WithMaxLiteralLength(100), want: ErrLiteralTooLong},
+		{name: "iri", prefix: `<http://example/s> <http://example/`, opt: WithMaxIRILength(100), want: ErrIRITooLong},
+		{name: "comment", prefix: `<http://example/s> <http://example/p> "a" . # `, opt: WithMaxLiteralLength(100), want: ErrStatementTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+
+			src := io.MultiReader(strings.NewReader(tc.prefix), endlessReader('a'))
+			r := NewReader(src, tc.opt)
+			if r.Next() {
+				t.Fatalf("got quad, wanted error")
+			}
+			if !errors.Is(r.Err(), tc.want) {
+				t.Errorf("got error %v, wanted %v", r.Err(), tc.want)
+			}
+
+			runtime.ReadMemStats(&after)
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 4<<20 {
+				t.Errorf("got %d bytes allocated, wanted at most %d", allocated, 4<<20)
+			}
+		})
+	}
+}
+
+func TestLimitSkipInvalid(t *testing.T) {
+	input := `<http://example/s> <http://example/p> "` + strings.Repeat("a", 100) + `" .
+<http://example/s> <http://example/p> "b" .
+`
+	r := NewReader(strings.NewReader(input), WithMaxStatementLength(60), WithSkipInvalid(nil))
+	n := 0
+	for r.Next() {
+		n++
+	}
+	if r.Err() != nil || n != 1 {
+		t.Errorf("got %d quads and error %v, wanted 1 quad", n, r.Err())
+	}
+}
+
+func TestMaxQuads(t *testing.T) {
+	statement := "<http://example/s> <http://example/p> \"1\" .\n"
+
+	testCases := []struct {
+		name    string
+		input   string
+		max     int
+		want    int
+		reached bool
+	}{
+		{name: "unlimited", input: strings.Repeat(statement, 3), max: 0, want: 3},
+		{name: "fewer", input: strings.Repeat(statement, 2), max: 3, want: 2},
+		{name: "exact", input: strings.Repeat(statement, 3) + "# trailing comment\n\n", max: 3, want: 3},
+		{name: "more", input: strings.Repeat(statement, 5), max: 3, want: 3, reached: true},
+		{name: "more-after-comments", input: strings.Repeat(statement, 3) + "# comment\n" + statement, max: 3, want: 3, reached: true},
+		{name: "malformed after limit", input: statement + "<http://example/s> <http://example/p> bad .\n", max: 1, want: 1, reached: true},
+		{name: "malformed after comment", input: statement + "  # comment\n\n\tbad\n", max: 1, want: 1, reached: true},
+		{name: "trailing comment", input: "<http://example/s> <http://example/p> \"1\" . # done\r\n  \n", max: 1, want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.input), WithMaxQuads(tc.max))
+			n := 0
+			for r.Next() {
+				n++
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if n != tc.want {
+				t.Errorf("got %d quads, wanted %d", n, tc.want)
+			}
+			if r.LimitReached() != tc.reached {
+				t.Errorf("got LimitReached %v, wanted %v", r.LimitReached(), tc.reached)
+			}
+			if r.Next() {
+				t.Errorf("Next returned true after the end of the quads")
+			}
+		})
+	}
+}
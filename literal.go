@@ -0,0 +1,156 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iand/gordf"
+)
+
+// ErrInvalidLexicalForm is the error returned by DecodeLiteral when the value of a literal is not valid for
+// its datatype.
+var ErrInvalidLexicalForm = errors.New("invalid lexical form for datatype")
+
+// integerRanges holds the range of values permitted by xsd:integer and the datatypes derived from it that
+// can be represented by an int64.
+var integerRanges = map[string][2]int64{
+	xsdInteger:                   {math.MinInt64, math.MaxInt64},
+	xsdNS + "long":               {math.MinInt64, math.MaxInt64},
+	xsdNS + "int":                {math.MinInt32, math.MaxInt32},
+	xsdNS + "short":              {math.MinInt16, math.MaxInt16},
+	xsdNS + "byte":               {math.MinInt8, math.MaxInt8},
+	xsdNS + "nonNegativeInteger": {0, math.MaxInt64},
+	xsdNS + "positiveInteger":    {1, math.MaxInt64},
+	xsdNS + "nonPositiveInteger": {math.MinInt64, 0},
+	xsdNS + "negativeInteger":    {math.MinInt64, -1},
+	xsdNS + "unsignedInt":        {0, math.MaxUint32},
+	xsdNS + "unsignedShort":      {0, math.MaxUint16},
+	xsdNS + "unsignedByte":       {0, math.MaxUint8},
+}
+
+// WithDecodeLiterals configures the Reader to decode the value of each literal object with a recognized
+// XSD datatype, as described by DecodeLiteral. The decoded value is returned by Value.
+func WithDecodeLiterals() Option {
+	return func(r *Reader) {
+		r.decodeLiterals = true
+	}
+}
+
+// Value returns the decoded value of the object of the last quad read if the Reader was configured using
+// WithDecodeLiterals. It returns nil if the object is not a literal, has a datatype that is not recognized
+// or has a value that is not valid for its datatype.
+func (r *Reader) Value() any {
+	return r.value
+}
+
+// DecodeLiteral decodes the value of the literal t according to its datatype. Literals with xsd:integer or
+// one of the integer datatypes derived from it that fit in an int64 are decoded as an int64, xsd:decimal,
+// xsd:double and xsd:float as a float64, xsd:boolean as a bool and xsd:dateTime, xsd:dateTimeStamp and
+// xsd:date as a time.Time. Times without a timezone are taken to be in UTC.
+//
+// DecodeLiteral returns nil and no error if t is not a literal or its datatype is not one of these, and
+// ErrInvalidLexicalForm if the value is not valid for the datatype or out of its range.
+func DecodeLiteral(t rdf.Term) (any, error) {
+	if t.Kind == EscapedLiteralTerm {
+		var err error
+		if t, err = UnescapeTerm(t); err != nil {
+			return nil, ErrInvalidLexicalForm
+		}
+	}
+	if t.Kind != rdf.LiteralTerm {
+		return nil, nil
+	}
+	// These datatypes all allow leading and trailing whitespace
+	s := strings.Trim(t.Value, " \t\n\r")
+
+	if bounds, ok := integerRanges[t.Datatype]; ok {
+		if !isDecimalLexical(s, false, false) {
+			return nil, ErrInvalidLexicalForm
+		}
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || v < bounds[0] || v > bounds[1] {
+			return nil, ErrInvalidLexicalForm
+		}
+		return v, nil
+	}
+
+	switch t.Datatype {
+	case xsdBoolean:
+		switch s {
+		case "true", "1":
+			return true, nil
+		case "false", "0":
+			return false, nil
+		}
+		return nil, ErrInvalidLexicalForm
+	case xsdDecimal, xsdDouble, xsdFloat:
+		floating := t.Datatype != xsdDecimal
+		if floating && (s == "INF" || s == "+INF" || s == "-INF" || s == "NaN") {
+			v, _ := strconv.ParseFloat(s, 64)
+			return v, nil
+		}
+		if !isDecimalLexical(s, true, floating) {
+			return nil, ErrInvalidLexicalForm
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil && (!errors.Is(err, strconv.ErrRange) || !floating) {
+			return nil, ErrInvalidLexicalForm
+		}
+		return v, nil
+	case xsdDateTime, xsdDateTimeStamp:
+		if v, err := time.Parse("2006-01-02T15:04:05Z07:00", s); err == nil {
+			return v, nil
+		}
+		if t.Datatype == xsdDateTime {
+			if v, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
+				return v, nil
+			}
+		}
+		return nil, ErrInvalidLexicalForm
+	case xsdDate:
+		for _, layout := range [...]string{"2006-01-02", "2006-01-02Z07:00"} {
+			if v, err := time.Parse(layout, s); err == nil {
+				return v, nil
+			}
+		}
+		return nil, ErrInvalidLexicalForm
+	}
+	return nil, nil
+}
+
+// isDecimalLexical reports whether s is an optionally signed sequence of digits, which may include a
+// decimal point if fraction is true and be followed by an exponent if exponent is true.
+func isDecimalLexical(s string, fraction, exponent bool) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if exponent {
+		if i := strings.IndexAny(s, "eE"); i >= 0 {
+			if !isDecimalLexical(s[i+1:], false, false) {
+				return false
+			}
+			s = s[:i]
+		}
+	}
+	digits := 0
+	point := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.' && fraction && !point:
+			point = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
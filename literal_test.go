@@ -0,0 +1,118 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iand/gordf"
+)
+
+func TestDecodeLiteral(t *testing.T) {
+	testCases := []struct {
+		value    string
+		datatype string
+		want     any
+		err      error
+	}{
+		{value: "42", datatype: xsdInteger, want: int64(42)},
+		{value: " -7\n", datatype: xsdInteger, want: int64(-7)},
+		{value: "+0012", datatype: xsdNS + "int", want: int64(12)},
+		{value: "9223372036854775807", datatype: xsdNS + "long", want: int64(math.MaxInt64)},
+		{value: "9223372036854775808", datatype: xsdInteger, err: ErrInvalidLexicalForm},
+		{value: "128", datatype: xsdNS + "byte", err: ErrInvalidLexicalForm},
+		{value: "-1", datatype: xsdNS + "nonNegativeInteger", err: ErrInvalidLexicalForm},
+		{value: "255", datatype: xsdNS + "unsignedByte", want: int64(255)},
+		{value: "1.0", datatype: xsdInteger, err: ErrInvalidLexicalForm},
+		{value: "1_000", datatype: xsdInteger, err: ErrInvalidLexicalForm},
+		{value: "", datatype: xsdInteger, err: ErrInvalidLexicalForm},
+		{value: "true", datatype: xsdBoolean, want: true},
+		{value: "0", datatype: xsdBoolean, want: false},
+		{value: "TRUE", datatype: xsdBoolean, err: ErrInvalidLexicalForm},
+		{value: "3.25", datatype: xsdDecimal, want: 3.25},
+		{value: "-.5", datatype: xsdDecimal, want: -0.5},
+		{value: "1e3", datatype: xsdDecimal, err: ErrInvalidLexicalForm},
+		{value: "INF", datatype: xsdDecimal, err: ErrInvalidLexicalForm},
+		{value: "1.5E-3", datatype: xsdDouble, want: 1.5e-3},
+		{value: "12", datatype: xsdFloat, want: 12.0},
+		{value: "-INF", datatype: xsdDouble, want: math.Inf(-1)},
+		{value: "1e400", datatype: xsdDouble, want: math.Inf(1)},
+		{value: "inf", datatype: xsdDouble, err: ErrInvalidLexicalForm},
+		{value: "0x10", datatype: xsdDouble, err: ErrInvalidLexicalForm},
+		{value: "1e", datatype: xsdDouble, err: ErrInvalidLexicalForm},
+		{value: "2024-02-29T13:45:30Z", datatype: xsdDateTime, want: time.Date(2024, 2, 29, 13, 45, 30, 0, time.UTC)},
+		{value: "2024-02-29T13:45:30.25+01:00", datatype: xsdDateTime, want: time.Date(2024, 2, 29, 12, 45, 30, 250000000, time.UTC)},
+		{value: "2024-02-29T13:45:30", datatype: xsdDateTime, want: time.Date(2024, 2, 29, 13, 45, 30, 0, time.UTC)},
+		{value: "2024-02-29T13:45:30", datatype: xsdDateTimeStamp, err: ErrInvalidLexicalForm},
+		{value: "2023-02-29T13:45:30Z", datatype: xsdDateTime, err: ErrInvalidLexicalForm},
+		{value: "2024-02-29", datatype: xsdDate, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-02-29Z", datatype: xsdDate, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{value: "29/02/2024", datatype: xsdDate, err: ErrInvalidLexicalForm},
+		{value: "text", datatype: xsdString, want: nil},
+		{value: "text", datatype: "http://example/dt", want: nil},
+	}
+
+	for _, tc := range testCases {
+		got, err := DecodeLiteral(rdf.LiteralWithDatatype(tc.value, tc.datatype))
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%q^^%s: got error %v, wanted %v", tc.value, tc.datatype, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q^^%s: got unexpected error %q", tc.value, tc.datatype, err)
+			continue
+		}
+		if tm, ok := got.(time.Time); ok {
+			if !tm.Equal(tc.want.(time.Time)) {
+				t.Errorf("%q^^%s: got %v, wanted %v", tc.value, tc.datatype, got, tc.want)
+			}
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q^^%s: got %#v, wanted %#v", tc.value, tc.datatype, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeLiterals(t *testing.T) {
+	input := `<http://example/s> <http://example/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example/s> <http://example/p> "plain" .
+<http://example/s> <http://example/p> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .
+<http://example/s> <http://example/p> <http://example/o> .
+<http://example/s> <http://example/p> "x"^^<http://www.w3.org/2001/XMLSchema#double> .
+`
+	want := []any{int64(1), nil, true, nil, nil}
+
+	r := NewReader(strings.NewReader(input), WithDecodeLiterals())
+	var got []any
+	for r.Next() {
+		got = append(got, r.Value())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d values, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %#v, wanted %#v", i, got[i], want[i])
+		}
+	}
+
+	r = NewReader(strings.NewReader(input))
+	for r.Next() {
+		if r.Value() != nil {
+			t.Errorf("got value %#v without WithDecodeLiterals", r.Value())
+		}
+	}
+}
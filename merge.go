@@ -0,0 +1,101 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strconv"
+
+	"github.com/iand/gordf"
+)
+
+// Merge adds the quads in other to d following the semantics of an RDF merge, in which blank nodes are
+// scoped to the dataset that holds them. Blank nodes in other whose labels are also used in d are given
+// new labels, formed by appending an underscore and a number to the original label, so that they are not
+// mistaken for the blank nodes of d. Blank nodes within quoted triples are relabelled in the same way.
+// Other quads are added unchanged and quads already present in d are ignored.
+//
+// The quads are added in the order determined by other.Order. Merging a dataset into itself adds a copy of
+// every quad that mentions a blank node.
+func (d *Dataset) Merge(other *Dataset) {
+	quads := other.Quads()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buildIndex()
+
+	used := make(map[string]bool)
+	for _, q := range d.quads {
+		collectBlankLabels(q, used)
+	}
+	if len(used) == 0 {
+		for _, q := range quads {
+			d.add(q)
+		}
+		return
+	}
+
+	// labels of other are reserved so that a new label cannot collide with one yet to be seen
+	reserved := make(map[string]bool)
+	for _, q := range quads {
+		collectBlankLabels(q, reserved)
+	}
+	renamed := make(map[string]string)
+	relabel := func(label string) string {
+		if !used[label] {
+			return label
+		}
+		if l, ok := renamed[label]; ok {
+			return l
+		}
+		for n := 1; ; n++ {
+			l := label + "_" + strconv.Itoa(n)
+			if !used[l] && !reserved[l] {
+				renamed[label] = l
+				reserved[l] = true
+				return l
+			}
+		}
+	}
+
+	for _, q := range quads {
+		d.add(Quad{
+			S: relabelBlankNodes(q.S, relabel),
+			P: q.P,
+			O: relabelBlankNodes(q.O, relabel),
+			G: relabelBlankNodes(q.G, relabel),
+		})
+	}
+}
+
+// collectBlankLabels adds the labels of the blank nodes in q, including those within quoted triples, to
+// labels.
+func collectBlankLabels(q Quad, labels map[string]bool) {
+	for _, t := range [...]rdf.Term{q.S, q.O, q.G} {
+		relabelBlankNodes(t, func(label string) string {
+			labels[label] = true
+			return label
+		})
+	}
+}
+
+// relabelBlankNodes returns t with the label of each blank node it holds replaced by the result of fn. A
+// blank node is returned with a new label and a quoted triple with the blank nodes of its subject and object
+// relabelled. Other terms are returned unchanged.
+func relabelBlankNodes(t rdf.Term, fn func(label string) string) rdf.Term {
+	switch t.Kind {
+	case rdf.BlankTerm:
+		return rdf.Blank(fn(t.Value))
+	case QuotedTripleTerm:
+		r := newStatementReader([]byte(t.Value + " ."))
+		if !r.next() {
+			// not produced by the Reader or QuotedTriple, so it cannot be relabelled
+			return t
+		}
+		q := r.Quad()
+		return QuotedTriple(relabelBlankNodes(q.S, fn), q.P, relabelBlankNodes(q.O, fn))
+	}
+	return t
+}
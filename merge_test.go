@@ -0,0 +1,74 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDatasetMerge(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "no blank nodes",
+			a:    "<http://example/s> <http://example/p> \"a\" .\n",
+			b:    "<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" .\n",
+			want: "<http://example/s> <http://example/p> \"a\" .\n<http://example/s> <http://example/p> \"b\" .\n",
+		},
+		{
+			name: "distinct blank nodes",
+			a:    "_:a <http://example/p> \"a\" .\n",
+			b:    "_:b <http://example/p> \"b\" .\n",
+			want: "_:a <http://example/p> \"a\" .\n_:b <http://example/p> \"b\" .\n",
+		},
+		{
+			name: "into empty",
+			a:    "",
+			b:    "_:b0 <http://example/p> _:b1 _:b0 .\n",
+			want: "_:b0 <http://example/p> _:b1 _:b0 .\n",
+		},
+		{
+			name: "colliding blank nodes",
+			a:    "_:b0 <http://example/p> \"a\" .\n",
+			b:    "_:b0 <http://example/p> _:b1 .\n_:b1 <http://example/p> \"c\" _:b0 .\n",
+			want: "_:b0 <http://example/p> \"a\" .\n_:b0_1 <http://example/p> _:b1 .\n_:b1 <http://example/p> \"c\" _:b0_1 .\n",
+		},
+		{
+			name: "new label in use",
+			a:    "_:x <http://example/p> _:x_1 .\n",
+			b:    "_:x <http://example/p> _:x_2 .\n",
+			want: "_:x <http://example/p> _:x_1 .\n_:x_3 <http://example/p> _:x_2 .\n",
+		},
+		{
+			name: "quoted triple",
+			a:    "_:a <http://example/p> \"a\" .\n",
+			b:    "<< _:a <http://example/p> \"a\" >> <http://example/q> _:a .\n",
+			want: "_:a <http://example/p> \"a\" .\n<< _:a_1 <http://example/p> \"a\" >> <http://example/q> _:a_1 .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := loadTestDataset(t, tc.a)
+			d.Merge(loadTestDataset(t, tc.b))
+			if got, want := d.Quads(), loadTestDataset(t, tc.want).Quads(); !slices.Equal(got, want) {
+				t.Errorf("got %v, wanted %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDatasetMergeSelf(t *testing.T) {
+	d := loadTestDataset(t, datasetInput)
+	d.Merge(d)
+	if d.Len() != 8 {
+		t.Errorf("got %d quads, wanted 8", d.Len())
+	}
+}
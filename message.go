@@ -0,0 +1,81 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+)
+
+var (
+	// ErrEmptyMessage is the error returned when a message is decoded that contains no statement.
+	ErrEmptyMessage = errors.New("message contains no quad")
+
+	// ErrTrailingData is the error returned when a message is decoded that contains more than the
+	// expected statement.
+	ErrTrailingData = errors.New("unexpected data following quad")
+)
+
+// EncodeMessage returns the N-Quads serialization of q for use as the payload of a single message in a
+// one-statement-per-message transport such as Kafka or NATS. The payload is not terminated by a newline.
+func EncodeMessage(q Quad) []byte {
+	return AppendQuad(nil, q)
+}
+
+// DecodeMessage decodes a message payload produced by EncodeMessage. The payload must contain exactly one
+// statement on a single line, optionally followed by a newline. Blank lines and additional statements are
+// rejected.
+func DecodeMessage(b []byte) (Quad, error) {
+	b = bytes.TrimSuffix(b, []byte{'\n'})
+	b = bytes.TrimSuffix(b, []byte{'\r'})
+	if len(bytes.TrimLeft(b, " \t")) == 0 {
+		return Quad{}, ErrEmptyMessage
+	}
+	if i := bytes.IndexAny(b, "\r\n"); i != -1 {
+		return Quad{}, ErrTrailingData
+	}
+
+	r := NewReader(bytes.NewReader(b))
+	if !r.Next() {
+		if r.Err() != nil {
+			return Quad{}, r.Err()
+		}
+		return Quad{}, ErrEmptyMessage
+	}
+	return r.Quad(), nil
+}
+
+// EncodeBatch returns the N-Quads serialization of quads for use as the payload of a single message.
+// Statements are separated by newlines and the payload is not terminated by a newline.
+func EncodeBatch(quads []Quad) []byte {
+	var dst []byte
+	for i, q := range quads {
+		if i > 0 {
+			dst = append(dst, '\n')
+		}
+		dst = AppendQuad(dst, q)
+	}
+	return dst
+}
+
+// DecodeBatch decodes a message payload produced by EncodeBatch. Each line of the payload must contain
+// exactly one statement. Blank lines are rejected.
+func DecodeBatch(b []byte) ([]Quad, error) {
+	b = bytes.TrimSuffix(b, []byte{'\n'})
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var quads []Quad
+	for _, line := range bytes.Split(b, []byte{'\n'}) {
+		q, err := DecodeMessage(line)
+		if err != nil {
+			return quads, err
+		}
+		quads = append(quads, q)
+	}
+	return quads, nil
+}
@@ -0,0 +1,162 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+var messageCases = []struct {
+	name    string
+	quad    Quad
+	encoded string
+}{
+	{
+		name: "triple",
+		quad: Quad{
+			S: rdf.IRI("http://example/s"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.Blank("o"),
+		},
+		encoded: `<http://example/s> <http://example/p> _:o .`,
+	},
+	{
+		name: "quad",
+		quad: Quad{
+			S: rdf.Blank("s"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.IRI("http://example/o"),
+			G: rdf.IRI("http://example/g"),
+		},
+		encoded: `_:s <http://example/p> <http://example/o> <http://example/g> .`,
+	},
+	{
+		name: "escaped-literal",
+		quad: Quad{
+			S: rdf.IRI("http://example/s"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.Literal("line1\nline2\r\"quoted\" \\ tab:\t é"),
+		},
+		encoded: `<http://example/s> <http://example/p> "line1\nline2\r\"quoted\" \\ tab:` + "\t" + ` é" .`,
+	},
+	{
+		name: "lang-literal",
+		quad: Quad{
+			S: rdf.IRI("http://example/s"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.LiteralWithLanguage("chat", "en-GB"),
+			G: rdf.Blank("g"),
+		},
+		encoded: `<http://example/s> <http://example/p> "chat"@en-GB _:g .`,
+	},
+	{
+		name: "typed-literal",
+		quad: Quad{
+			S: rdf.IRI("http://example/s"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.LiteralWithDatatype("1", "http://www.w3.org/2001/XMLSchema#integer"),
+		},
+		encoded: `<http://example/s> <http://example/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+	},
+	{
+		name: "escaped-iri",
+		quad: Quad{
+			S: rdf.IRI("http://example/s p"),
+			P: rdf.IRI("http://example/p"),
+			O: rdf.IRI("http://example/<o>"),
+		},
+		encoded: `<http://example/s\u0020p> <http://example/p> <http://example/\u003Co\u003E> .`,
+	},
+}
+
+func TestEncodeMessage(t *testing.T) {
+	for _, tc := range messageCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := string(EncodeMessage(tc.quad))
+			if got != tc.encoded {
+				t.Errorf("got %s, wanted %s", got, tc.encoded)
+			}
+		})
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	for _, tc := range messageCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeMessage([]byte(tc.encoded))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if got != tc.quad {
+				t.Errorf("got %s, wanted %s", got, tc.quad)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageErrors(t *testing.T) {
+	testCases := []struct {
+		input string
+		err   error
+	}{
+		{
+			input: "",
+			err:   ErrEmptyMessage,
+		},
+		{
+			input: "  \n",
+			err:   ErrEmptyMessage,
+		},
+		{
+			input: "<http://example/s> <http://example/p> <http://example/o> .\n<http://example/s> <http://example/p> <http://example/o> .",
+			err:   ErrTrailingData,
+		},
+		{
+			input: "\n<http://example/s> <http://example/p> <http://example/o> .",
+			err:   ErrTrailingData,
+		},
+		{
+			input: "<http://example/s> <http://example/p>",
+			err:   ErrUnexpectedEOF,
+		},
+		{
+			input: "<http://example/s> <http://example/p> <http://example/o>",
+			err:   ErrUnterminatedQuad,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			_, err := DecodeMessage([]byte(tc.input))
+			if !errors.Is(err, tc.err) {
+				t.Errorf("got error %v, wanted %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestBatchRoundTrip(t *testing.T) {
+	quads := make([]Quad, 0, len(messageCases))
+	for _, tc := range messageCases {
+		quads = append(quads, tc.quad)
+	}
+
+	got, err := DecodeBatch(EncodeBatch(quads))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if len(got) != len(quads) {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(quads))
+	}
+	for i := range quads {
+		if got[i] != quads[i] {
+			t.Errorf("quad %d: got %s, wanted %s", i, got[i], quads[i])
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/iand/gordf"
+)
+
+// Metadata describes a dataset so that a published dump can carry provenance information about itself.
+type Metadata struct {
+	Dataset rdf.Term  // the term identifying the dataset, the subject of each metadata quad
+	Graph   rdf.Term  // the graph the metadata quads are placed in, the default graph if of unknown kind
+	Count   int64     // the number of quads in the dataset
+	Created time.Time // the time the dataset was created, omitted if zero
+	Source  string    // the IRI of the source the dataset was derived from, omitted if empty
+	SHA256  string    // the hex encoded SHA-256 digest of the dataset, omitted if empty
+}
+
+// Quads returns quads describing the dataset using the VoID, Dublin Core and schema.org vocabularies.
+// The dataset is typed as a void:Dataset and its count is given by void:triples.
+func (m Metadata) Quads() []Quad {
+	quads := []Quad{
+		{S: m.Dataset, P: rdf.IRI(rdfType), O: rdf.IRI(voidNS + "Dataset"), G: m.Graph},
+		{S: m.Dataset, P: rdf.IRI(voidNS + "triples"), O: rdf.LiteralWithDatatype(strconv.FormatInt(m.Count, 10), xsdInteger), G: m.Graph},
+	}
+	if !m.Created.IsZero() {
+		quads = append(quads, Quad{S: m.Dataset, P: rdf.IRI(dctermsNS + "created"), O: rdf.LiteralWithDatatype(m.Created.UTC().Format(time.RFC3339Nano), xsdDateTime), G: m.Graph})
+	}
+	if m.Source != "" {
+		quads = append(quads, Quad{S: m.Dataset, P: rdf.IRI(dctermsNS + "source"), O: rdf.IRI(m.Source), G: m.Graph})
+	}
+	if m.SHA256 != "" {
+		quads = append(quads, Quad{S: m.Dataset, P: rdf.IRI(schemaNS + "sha256"), O: rdf.Literal(m.SHA256), G: m.Graph})
+	}
+	return quads
+}
+
+// A MetadataWriter writes quads to a Writer while recording them, so that once writing is complete the
+// Metadata describing the dataset written can be filled in with its quad count and canonical hash. The
+// quads written are held in memory since the canonical hash can only be computed over the whole dataset.
+type MetadataWriter struct {
+	w *Writer
+	d *Dataset
+}
+
+// NewMetadataWriter returns a new MetadataWriter that writes quads to w.
+func NewMetadataWriter(w *Writer) *MetadataWriter {
+	return &MetadataWriter{w: w, d: NewDataset()}
+}
+
+// Write writes a single quad to the underlying Writer and records it as part of the dataset.
+func (mw *MetadataWriter) Write(q Quad) error {
+	if err := mw.w.Write(q); err != nil {
+		return err
+	}
+	mw.d.Add(q)
+	return nil
+}
+
+// Metadata returns m with Count set to the number of distinct quads written and SHA256 set to the
+// canonical hash of the dataset they form, as returned by Dataset.Hash.
+func (mw *MetadataWriter) Metadata(m Metadata) (Metadata, error) {
+	hash, err := mw.d.Hash()
+	if err != nil {
+		return m, err
+	}
+	m.Count = int64(mw.d.Len())
+	m.SHA256 = hash
+	return m, nil
+}
+
+// WriteMetadata completes m as Metadata does and writes the quads describing the dataset to the underlying
+// Writer. The metadata quads are not recorded, so they are not counted or hashed as part of the dataset.
+func (mw *MetadataWriter) WriteMetadata(m Metadata) error {
+	m, err := mw.Metadata(m)
+	if err != nil {
+		return err
+	}
+	for _, q := range m.Quads() {
+		if err := mw.w.Write(q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
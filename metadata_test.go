@@ -0,0 +1,111 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iand/gordf"
+)
+
+func TestMetadataQuads(t *testing.T) {
+	m := Metadata{
+		Dataset: rdf.IRI("http://example/dataset"),
+		Graph:   rdf.IRI("http://example/meta"),
+		Count:   1234,
+		Created: time.Date(2024, 4, 4, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		Source:  "http://example/source.nq",
+		SHA256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, q := range m.Quads() {
+		if err := w.Write(q); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	w.Flush()
+
+	want := `<http://example/dataset> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://rdfs.org/ns/void#Dataset> <http://example/meta> .
+<http://example/dataset> <http://rdfs.org/ns/void#triples> "1234"^^<http://www.w3.org/2001/XMLSchema#integer> <http://example/meta> .
+<http://example/dataset> <http://purl.org/dc/terms/created> "2024-04-04T10:30:00Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> <http://example/meta> .
+<http://example/dataset> <http://purl.org/dc/terms/source> <http://example/source.nq> <http://example/meta> .
+<http://example/dataset> <http://schema.org/sha256> "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" <http://example/meta> .
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestMetadataQuadsMinimal(t *testing.T) {
+	m := Metadata{Dataset: rdf.Blank("dataset")}
+	quads := m.Quads()
+	if len(quads) != 2 {
+		t.Fatalf("got %d quads, wanted 2", len(quads))
+	}
+	for _, q := range quads {
+		if q.G.Kind != rdf.UnknownTerm {
+			t.Errorf("got graph %q, wanted default graph", q.G.Value)
+		}
+	}
+}
+
+func TestMetadataWriter(t *testing.T) {
+	input := `_:a <http://example/p> "1" .
+_:b <http://example/p> _:a <http://example/g> .
+_:a <http://example/p> "1" .
+`
+	quads, err := ParseString(input)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	mw := NewMetadataWriter(w)
+	for _, q := range quads {
+		if err := mw.Write(q); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	m := Metadata{Dataset: rdf.IRI("http://example/dataset"), Graph: rdf.IRI("http://example/meta")}
+	if err := mw.WriteMetadata(m); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	w.Flush()
+
+	d := NewDataset()
+	for _, q := range quads {
+		d.Add(q)
+	}
+	hash, err := d.Hash()
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	got, err := ParseString(buf.String())
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if len(got) != len(quads)+3 {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(quads)+3)
+	}
+	meta := got[len(quads):]
+	if want := rdf.LiteralWithDatatype("2", xsdInteger); meta[1].O != want {
+		t.Errorf("got count %s, wanted %s", meta[1].O.String(), want.String())
+	}
+	if want := rdf.Literal(hash); meta[2].O != want {
+		t.Errorf("got hash %s, wanted %s", meta[2].O.String(), want.String())
+	}
+	for _, q := range meta {
+		if q.G != m.Graph {
+			t.Errorf("got graph %s, wanted %s", q.G.String(), m.Graph.String())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"time"
+)
+
+// ReaderStats reports the progress of a Reader, as returned by Stats.
+type ReaderStats struct {
+	Bytes   int64         // the number of bytes of input consumed
+	Quads   int64         // the number of quads returned
+	Skipped int64         // the number of statements skipped because of syntax errors
+	Elapsed time.Duration // the time since the Reader started reading
+}
+
+// Stats returns the progress of r so far. Statements are only skipped if r was configured using
+// WithSkipInvalid. Elapsed is measured from the first call to a method that reads a statement, such as
+// Next, and is zero before then.
+func (r *Reader) Stats() ReaderStats {
+	s := ReaderStats{
+		Bytes:   r.offset,
+		Quads:   int64(r.nquads),
+		Skipped: r.skipped,
+	}
+	if !r.started.IsZero() {
+		s.Elapsed = time.Since(r.started)
+	}
+	return s
+}
+
+// WithProgress configures the Reader to call fn with the statistics returned by Stats after every n quads
+// it returns, so that long running reads can report their progress. If n is not positive it defaults to
+// 100000. The progress of a ParallelReader is not reported.
+func WithProgress(n int, fn func(ReaderStats)) Option {
+	return func(r *Reader) {
+		if n <= 0 {
+			n = defaultProgressInterval
+		}
+		r.onProgress = fn
+		r.progressEvery = n
+	}
+}
+
+// defaultProgressInterval is the number of quads between calls made by WithProgress when a non-positive
+// number is supplied.
+const defaultProgressInterval = 100000
+
+// BytesPerSecond returns the average number of bytes consumed per second, or zero if no time has elapsed.
+func (s ReaderStats) BytesPerSecond() float64 {
+	return perSecond(s.Bytes, s.Elapsed)
+}
+
+// QuadsPerSecond returns the average number of quads returned per second, or zero if no time has elapsed.
+func (s ReaderStats) QuadsPerSecond() float64 {
+	return perSecond(s.Quads, s.Elapsed)
+}
+
+func perSecond(n int64, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(n) / d.Seconds()
+}
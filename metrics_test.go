@@ -0,0 +1,79 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderStats(t *testing.T) {
+	input := datasetInput + "bad statement\n<http://example/s> <http://example/p> \"x\" .\n"
+	r := NewReader(strings.NewReader(input), WithSkipInvalid(nil))
+	if s := r.Stats(); s != (ReaderStats{}) {
+		t.Errorf("got stats %+v before reading, wanted none", s)
+	}
+	for r.Next() {
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+
+	s := r.Stats()
+	if s.Bytes != int64(len(input)) || s.Quads != 9 || s.Skipped != 1 {
+		t.Errorf("got bytes=%d quads=%d skipped=%d, wanted bytes=%d quads=9 skipped=1", s.Bytes, s.Quads, s.Skipped, len(input))
+	}
+	if s.Elapsed <= 0 {
+		t.Errorf("got elapsed time %v, wanted a positive duration", s.Elapsed)
+	}
+}
+
+func TestReaderStatsRates(t *testing.T) {
+	testCases := []struct {
+		stats ReaderStats
+		bytes float64
+		quads float64
+	}{
+		{stats: ReaderStats{Bytes: 1000, Quads: 10, Elapsed: 2 * time.Second}, bytes: 500, quads: 5},
+		{stats: ReaderStats{Bytes: 1000, Quads: 10}, bytes: 0, quads: 0},
+	}
+	for _, tc := range testCases {
+		if got := tc.stats.BytesPerSecond(); got != tc.bytes {
+			t.Errorf("%+v: got %v bytes per second, wanted %v", tc.stats, got, tc.bytes)
+		}
+		if got := tc.stats.QuadsPerSecond(); got != tc.quads {
+			t.Errorf("%+v: got %v quads per second, wanted %v", tc.stats, got, tc.quads)
+		}
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	testCases := []struct {
+		n    int
+		want []int64
+	}{
+		{n: 3, want: []int64{3, 6}},
+		{n: 8, want: []int64{8}},
+		{n: 9},
+		{n: 0},
+	}
+	for _, tc := range testCases {
+		var got []int64
+		r := NewReader(strings.NewReader(datasetInput), WithProgress(tc.n, func(s ReaderStats) {
+			got = append(got, s.Quads)
+		}))
+		for r.Next() {
+		}
+		if r.Err() != nil {
+			t.Fatalf("got unexpected error %q", r.Err())
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("n=%d: got progress at %v quads, wanted %v", tc.n, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"os"
+)
+
+// mmapFile reports that files cannot be memory mapped on this platform.
+func mmapFile(f *os.File) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// munmap does nothing since files are never mapped on this platform.
+func munmap(b []byte) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+
+	"github.com/iand/gordf"
+)
+
+// A MultiFileWriter writes quads to a numbered sequence of files, producing a dump that is split into
+// shards. A new file is started once the current file holds MaxQuads quads or MaxBytes bytes of
+// uncompressed output. If ByGraph is true each graph is written to its own sequence of files, with the
+// limits applying to each file separately. The exported fields can be changed before the first call to
+// Write.
+//
+// Files are named by formatting the pattern passed to NewMultiFileWriter with the number of the file,
+// starting at zero, and are created using CreateFile so a pattern such as "dump-%04d.nq.gz" produces gzip
+// compressed files. When writing by graph, one file per graph is held open until Close is called.
+type MultiFileWriter struct {
+	MaxQuads int64 // the maximum number of quads in each file, unlimited if zero
+	MaxBytes int64 // the maximum number of uncompressed bytes in each file, unlimited if zero
+	ByGraph  bool  // true to write each graph to separate files
+
+	pattern string
+	open    map[rdf.Term]*shardFile // the file currently being written for each graph, or for all quads
+	files   []ShardFile
+}
+
+// A ShardFile describes a file written by a MultiFileWriter.
+type ShardFile struct {
+	Name  string   // the name of the file
+	Graph rdf.Term // the graph held by the file if the MultiFileWriter writes by graph
+	Quads int64    // the number of quads written to the file
+}
+
+type shardFile struct {
+	w     *WriteCloser
+	index int // index of the file's entry in files
+	bytes int64
+}
+
+// NewMultiFileWriter returns a new MultiFileWriter that names files using pattern, which must contain a
+// single formatting verb for an integer such as %d.
+func NewMultiFileWriter(pattern string) *MultiFileWriter {
+	return &MultiFileWriter{
+		pattern: pattern,
+		open:    make(map[rdf.Term]*shardFile),
+	}
+}
+
+// Write writes a single quad, creating a new file if needed.
+func (m *MultiFileWriter) Write(q Quad) error {
+	var key rdf.Term
+	if m.ByGraph {
+		key = q.G
+	}
+
+	f := m.open[key]
+	if f != nil && m.full(f) {
+		delete(m.open, key)
+		if err := f.w.Close(); err != nil {
+			return err
+		}
+		f = nil
+	}
+	if f == nil {
+		name := fmt.Sprintf(m.pattern, len(m.files))
+		w, err := CreateFile(name)
+		if err != nil {
+			return err
+		}
+		f = &shardFile{w: w, index: len(m.files)}
+		m.files = append(m.files, ShardFile{Name: name, Graph: key})
+		m.open[key] = f
+	}
+
+	if err := f.w.Write(q); err != nil {
+		return err
+	}
+	m.files[f.index].Quads++
+	f.bytes += int64(len(f.w.buf)) // the statement just written
+	return nil
+}
+
+// full reports whether f has reached the limits on the size of a file.
+func (m *MultiFileWriter) full(f *shardFile) bool {
+	return (m.MaxQuads > 0 && m.files[f.index].Quads >= m.MaxQuads) || (m.MaxBytes > 0 && f.bytes >= m.MaxBytes)
+}
+
+// Close closes all open files, returning the first error encountered.
+func (m *MultiFileWriter) Close() error {
+	var err error
+	for key, f := range m.open {
+		if cerr := f.w.Close(); err == nil {
+			err = cerr
+		}
+		delete(m.open, key)
+	}
+	return err
+}
+
+// Files returns a description of each file created, in the order they were created.
+func (m *MultiFileWriter) Files() []ShardFile {
+	return append([]ShardFile(nil), m.files...)
+}
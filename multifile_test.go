@@ -0,0 +1,132 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestMultiFileWriterMaxQuads(t *testing.T) {
+	dir := t.TempDir()
+	m := NewMultiFileWriter(filepath.Join(dir, "part-%02d.nq"))
+	m.MaxQuads = 2
+	for i := 0; i < 5; i++ {
+		q := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal(strconv.Itoa(i))}
+		if err := m.Write(q); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	files := m.Files()
+	wantQuads := []int64{2, 2, 1}
+	if len(files) != len(wantQuads) {
+		t.Fatalf("got %d files, wanted %d", len(files), len(wantQuads))
+	}
+	for i, f := range files {
+		if want := filepath.Join(dir, "part-0"+strconv.Itoa(i)+".nq"); f.Name != want {
+			t.Errorf("got name %s, wanted %s", f.Name, want)
+		}
+		if f.Quads != wantQuads[i] {
+			t.Errorf("%s: got %d quads, wanted %d", f.Name, f.Quads, wantQuads[i])
+		}
+		if got := countFileQuads(t, f.Name); got != wantQuads[i] {
+			t.Errorf("%s: read %d quads, wanted %d", f.Name, got, wantQuads[i])
+		}
+	}
+}
+
+func TestMultiFileWriterMaxBytes(t *testing.T) {
+	q := messageCases[0].quad
+	size := int64(len(messageCases[0].encoded) + 1)
+
+	dir := t.TempDir()
+	m := NewMultiFileWriter(filepath.Join(dir, "part-%d.nq"))
+	m.MaxBytes = 2*size + 1
+	for i := 0; i < 4; i++ {
+		if err := m.Write(q); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	files := m.Files()
+	if len(files) != 2 || files[0].Quads != 3 || files[1].Quads != 1 {
+		t.Errorf("got files %+v, wanted 3 quads then 1", files)
+	}
+}
+
+func TestMultiFileWriterByGraph(t *testing.T) {
+	graphs := []rdf.Term{rdf.IRI("http://example/g1"), {}, rdf.IRI("http://example/g1"), rdf.Blank("g2"), {}}
+
+	dir := t.TempDir()
+	m := NewMultiFileWriter(filepath.Join(dir, "graph-%d.nq.gz"))
+	m.ByGraph = true
+	for i, g := range graphs {
+		q := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal(strconv.Itoa(i)), G: g}
+		if err := m.Write(q); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	want := []ShardFile{
+		{Name: filepath.Join(dir, "graph-0.nq.gz"), Graph: rdf.IRI("http://example/g1"), Quads: 2},
+		{Name: filepath.Join(dir, "graph-1.nq.gz"), Quads: 2},
+		{Name: filepath.Join(dir, "graph-2.nq.gz"), Graph: rdf.Blank("g2"), Quads: 1},
+	}
+	files := m.Files()
+	if len(files) != len(want) {
+		t.Fatalf("got %d files, wanted %d", len(files), len(want))
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("got file %+v, wanted %+v", files[i], want[i])
+		}
+		if got := countFileQuads(t, files[i].Name); got != want[i].Quads {
+			t.Errorf("%s: read %d quads, wanted %d", files[i].Name, got, want[i].Quads)
+		}
+	}
+}
+
+func countFileQuads(t *testing.T, name string) int64 {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	defer f.Close()
+
+	var src io.Reader = f
+	if CompressionFor(name) == Gzip {
+		if src, err = gzip.NewReader(f); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+
+	r := NewReader(src)
+	var n int64
+	for r.Next() {
+		n++
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	return n
+}
@@ -0,0 +1,250 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gordf"
+)
+
+// A PrefixMap maps prefixes to the namespace IRIs they abbreviate.
+type PrefixMap map[string]string
+
+// WellKnownPrefixes holds the conventional prefixes of widely used vocabularies.
+var WellKnownPrefixes = PrefixMap{
+	"dcat":    "http://www.w3.org/ns/dcat#",
+	"dc":      "http://purl.org/dc/elements/1.1/",
+	"dcterms": dctermsNS,
+	"foaf":    "http://xmlns.com/foaf/0.1/",
+	"geo":     "http://www.w3.org/2003/01/geo/wgs84_pos#",
+	"owl":     "http://www.w3.org/2002/07/owl#",
+	"prov":    "http://www.w3.org/ns/prov#",
+	"rdf":     rdfNS,
+	"rdfs":    "http://www.w3.org/2000/01/rdf-schema#",
+	"schema":  schemaNS,
+	"sh":      shNS,
+	"skos":    "http://www.w3.org/2004/02/skos/core#",
+	"vcard":   "http://www.w3.org/2006/vcard/ns#",
+	"void":    voidNS,
+	"xsd":     xsdNS,
+}
+
+// A NamespaceCount is the number of times IRIs in a namespace were used.
+type NamespaceCount struct {
+	Namespace string
+	Count     int
+}
+
+// A NamespaceAnalyzer counts the namespaces of the IRIs used in a stream of quads, including the datatype
+// IRIs of literals.
+type NamespaceAnalyzer struct {
+	counts map[string]int
+}
+
+// AnalyzeNamespaces reads all quads from r and returns an analyzer holding the namespaces they use.
+func AnalyzeNamespaces(r *Reader) (*NamespaceAnalyzer, error) {
+	a := &NamespaceAnalyzer{}
+	for r.Next() {
+		a.Add(r.Quad())
+	}
+	return a, r.Err()
+}
+
+// Add counts the namespaces of the IRIs in q.
+func (a *NamespaceAnalyzer) Add(q Quad) {
+	a.addTerm(q.S)
+	a.addTerm(q.P)
+	a.addTerm(q.O)
+	a.addTerm(q.G)
+}
+
+func (a *NamespaceAnalyzer) addTerm(t rdf.Term) {
+	var iri string
+	switch {
+	case t.Kind == rdf.IRITerm:
+		iri = t.Value
+	case isLiteral(t) && t.Datatype != "":
+		iri = t.Datatype
+	default:
+		return
+	}
+
+	if a.counts == nil {
+		a.counts = make(map[string]int)
+	}
+	a.counts[namespaceOf(iri)]++
+}
+
+// Namespaces returns the count of each namespace, ordered by descending count. Namespaces with equal counts
+// are ordered lexically.
+func (a *NamespaceAnalyzer) Namespaces() []NamespaceCount {
+	counts := make([]NamespaceCount, 0, len(a.counts))
+	for ns, n := range a.counts {
+		counts = append(counts, NamespaceCount{Namespace: ns, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Namespace < counts[j].Namespace
+	})
+	return counts
+}
+
+// SuggestPrefixes returns a prefix map for the namespaces used at least minCount times. Namespaces of well
+// known vocabularies are given their conventional prefix. Other namespaces are given a prefix derived from
+// the last segment of the namespace IRI, or ns1, ns2 and so on when no suitable segment exists.
+func (a *NamespaceAnalyzer) SuggestPrefixes(minCount int) PrefixMap {
+	known := make(map[string]string, len(WellKnownPrefixes))
+	for prefix, ns := range WellKnownPrefixes {
+		known[ns] = prefix
+	}
+
+	// Assign the well known prefixes first so they cannot be claimed by a derived prefix
+	pm := PrefixMap{}
+	var unknown []string
+	for _, nc := range a.Namespaces() {
+		if nc.Count < minCount {
+			break
+		}
+		if prefix, ok := known[nc.Namespace]; ok {
+			pm[prefix] = nc.Namespace
+			continue
+		}
+		unknown = append(unknown, nc.Namespace)
+	}
+
+	taken := func(prefix string) bool {
+		_, inMap := pm[prefix]
+		_, isKnown := WellKnownPrefixes[prefix]
+		return inMap || isKnown
+	}
+
+	for _, ns := range unknown {
+		prefix := derivePrefix(ns)
+		if prefix == "" {
+			prefix = "ns"
+		}
+		if prefix == "ns" || taken(prefix) {
+			base := prefix
+			for i := 1; taken(prefix) || prefix == base; i++ {
+				prefix = base + strconv.Itoa(i)
+			}
+		}
+		pm[prefix] = ns
+	}
+	return pm
+}
+
+// namespaceOf returns the namespace of iri, which is everything up to and including the last '#', '/' or ':'.
+func namespaceOf(iri string) string {
+	if i := strings.LastIndexAny(iri, "#/:"); i != -1 {
+		return iri[:i+1]
+	}
+	return iri
+}
+
+// derivePrefix returns a lowercase prefix made from the ASCII letters of the last non-empty segment of
+// namespace ns, or the empty string if there is no suitable segment.
+func derivePrefix(ns string) string {
+	segments := strings.FieldsFunc(ns, func(r rune) bool { return r == '/' || r == '#' || r == ':' })
+	if len(segments) < 2 {
+		// Only a scheme, or nothing at all
+		return ""
+	}
+	segment := segments[len(segments)-1]
+	if len(segments) == 2 {
+		// Use the most specific part of a host name such as www.example.org
+		parts := strings.Split(segment, ".")
+		if len(parts) > 1 {
+			segment = parts[len(parts)-2]
+		}
+	}
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(segment) {
+		if r >= 'a' && r <= 'z' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// Compact abbreviates iri as a prefixed name using the longest namespace in pm that iri starts with. It
+// reports false and returns iri unchanged if no namespace matches or the remainder of iri is not a simple
+// local name consisting of letters, digits, '_', '-' and '.', not ending with '.'.
+func (pm PrefixMap) Compact(iri string) (string, bool) {
+	best := ""
+	for prefix, ns := range pm {
+		if !strings.HasPrefix(iri, ns) || !isSimpleLocalName(iri[len(ns):]) {
+			continue
+		}
+		if best == "" || len(ns) > len(pm[best]) || (len(ns) == len(pm[best]) && prefix < best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return iri, false
+	}
+	return best + ":" + iri[len(pm[best]):], true
+}
+
+// Expand returns the IRI abbreviated by the prefixed name pname and reports whether its prefix is in pm.
+func (pm PrefixMap) Expand(pname string) (string, bool) {
+	prefix, local, ok := strings.Cut(pname, ":")
+	if !ok {
+		return pname, false
+	}
+	ns, ok := pm[prefix]
+	if !ok {
+		return pname, false
+	}
+	return ns + local, true
+}
+
+// FormatTerm returns a human readable representation of t intended for logging and debugging, with IRIs
+// abbreviated as prefixed names using pm where possible. The result is not valid N-Quads.
+func FormatTerm(t rdf.Term, pm PrefixMap) string {
+	switch t.Kind {
+	case rdf.IRITerm:
+		if pname, ok := pm.Compact(t.Value); ok {
+			return pname
+		}
+	case rdf.LiteralTerm:
+		if t.Language == "" && t.Datatype != "" {
+			if pname, ok := pm.Compact(t.Datatype); ok {
+				return string(appendString(nil, t.Value)) + "^^" + pname
+			}
+		}
+	}
+	return termText(t)
+}
+
+// FormatQuad returns a human readable representation of q intended for logging and debugging, with IRIs
+// abbreviated as prefixed names using pm where possible. The result is not valid N-Quads.
+func FormatQuad(q Quad, pm PrefixMap) string {
+	s := FormatTerm(q.S, pm) + " " + FormatTerm(q.P, pm) + " " + FormatTerm(q.O, pm)
+	if q.G.Kind != rdf.UnknownTerm {
+		s += " " + FormatTerm(q.G, pm)
+	}
+	return s + " ."
+}
+
+// isSimpleLocalName reports whether s can be written as the local part of a prefixed name without escaping.
+func isSimpleLocalName(s string) bool {
+	if strings.HasSuffix(s, ".") {
+		return false
+	}
+	for _, r := range s {
+		if !isAlpha(r) && !isNumeral(r) && r != '_' && r != '-' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+)
+
+const namespaceInput = `<http://example.org/people/alice> <http://xmlns.com/foaf/0.1/name> "Alice" <http://example.org/graphs/g1> .
+<http://example.org/people/alice> <http://xmlns.com/foaf/0.1/age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/people/bob> <http://xmlns.com/foaf/0.1/knows> <http://example.org/people/alice> .
+<http://example.org/people/bob> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://data.example.com/people/Person> .
+<urn:isbn:0451450523> <http://purl.org/dc/terms/title> "The Last Unicorn" .
+`
+
+func TestNamespaceAnalyzer(t *testing.T) {
+	a, err := AnalyzeNamespaces(NewReader(strings.NewReader(namespaceInput)))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	want := []NamespaceCount{
+		{Namespace: "http://example.org/people/", Count: 5},
+		{Namespace: "http://xmlns.com/foaf/0.1/", Count: 3},
+		{Namespace: "http://data.example.com/people/", Count: 1},
+		{Namespace: "http://example.org/graphs/", Count: 1},
+		{Namespace: "http://purl.org/dc/terms/", Count: 1},
+		{Namespace: "http://www.w3.org/1999/02/22-rdf-syntax-ns#", Count: 1},
+		{Namespace: "http://www.w3.org/2001/XMLSchema#", Count: 1},
+		{Namespace: "urn:isbn:", Count: 1},
+	}
+	got := a.Namespaces()
+	if len(got) != len(want) {
+		t.Fatalf("got %d namespaces %v, wanted %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("namespace %d: got %v, wanted %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSuggestPrefixes(t *testing.T) {
+	a, err := AnalyzeNamespaces(NewReader(strings.NewReader(namespaceInput)))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	want := PrefixMap{
+		"people":  "http://example.org/people/",
+		"foaf":    "http://xmlns.com/foaf/0.1/",
+		"people1": "http://data.example.com/people/",
+		"graphs":  "http://example.org/graphs/",
+		"dcterms": "http://purl.org/dc/terms/",
+		"rdf":     "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"xsd":     "http://www.w3.org/2001/XMLSchema#",
+		"isbn":    "urn:isbn:",
+	}
+	got := a.SuggestPrefixes(1)
+	if len(got) != len(want) {
+		t.Errorf("got %d prefixes %v, wanted %d", len(got), got, len(want))
+	}
+	for prefix, ns := range want {
+		if got[prefix] != ns {
+			t.Errorf("prefix %s: got %q, wanted %q", prefix, got[prefix], ns)
+		}
+	}
+
+	if got := a.SuggestPrefixes(2); len(got) != 2 {
+		t.Errorf("got %d prefixes %v with minimum count 2, wanted 2", len(got), got)
+	}
+}
+
+func TestDerivePrefix(t *testing.T) {
+	testCases := []struct {
+		ns   string
+		want string
+	}{
+		{ns: "http://example.org/ontology#", want: "ontology"},
+		{ns: "http://www.example.org/", want: "example"},
+		{ns: "http://example.org/v1.2/", want: "v"},
+		{ns: "http://example.org/2020/", want: ""},
+		{ns: "urn:", want: ""},
+	}
+
+	for _, tc := range testCases {
+		if got := derivePrefix(tc.ns); got != tc.want {
+			t.Errorf("%s: got %q, wanted %q", tc.ns, got, tc.want)
+		}
+	}
+}
+
+func TestPrefixMapCompact(t *testing.T) {
+	pm := PrefixMap{
+		"ex":   "http://example.org/",
+		"exp":  "http://example.org/people/",
+		"foaf": "http://xmlns.com/foaf/0.1/",
+	}
+
+	testCases := []struct {
+		iri  string
+		want string
+		ok   bool
+	}{
+		{iri: "http://example.org/people/alice", want: "exp:alice", ok: true},
+		{iri: "http://example.org/thing", want: "ex:thing", ok: true},
+		{iri: "http://example.org/", want: "ex:", ok: true},
+		{iri: "http://xmlns.com/foaf/0.1/name", want: "foaf:name", ok: true},
+		{iri: "http://example.org/a/b?c", want: "http://example.org/a/b?c", ok: false},
+		{iri: "http://example.org/end.", want: "http://example.org/end.", ok: false},
+		{iri: "http://other.org/x", want: "http://other.org/x", ok: false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := pm.Compact(tc.iri)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: got %q, %v, wanted %q, %v", tc.iri, got, ok, tc.want, tc.ok)
+		}
+		if ok {
+			if iri, _ := pm.Expand(got); iri != tc.iri {
+				t.Errorf("%s: expanded to %q", got, iri)
+			}
+		}
+	}
+}
+
+func TestFormatQuad(t *testing.T) {
+	r := NewReader(strings.NewReader(namespaceInput))
+	if !r.Next() || !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+
+	pm := PrefixMap{
+		"foaf": "http://xmlns.com/foaf/0.1/",
+		"xsd":  "http://www.w3.org/2001/XMLSchema#",
+	}
+	want := `<http://example.org/people/alice> foaf:age "42"^^xsd:integer .`
+	if got := FormatQuad(r.Quad(), pm); got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
@@ -9,10 +9,13 @@ package nquads
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/iand/gordf"
 )
@@ -54,13 +57,86 @@ var (
 	ErrRelativeIRI = errors.New("relative IRI")
 )
 
+// A Reader reads quads from an N-Quads encoded input.
 type Reader struct {
-	line   int
-	column int
-	r      *bufio.Reader
-	buf    bytes.Buffer
-	err    error
-	q      Quad
+	line    int
+	column  int
+	newline bool     // whether the last rune read ended a line, so the next rune starts a new line
+	cr      bool     // whether the last rune read was a carriage return
+	crlf    bool     // whether the last rune read was the line feed of a \r\n pair
+	eol     string   // the line terminator of the first line, if line endings are checked
+	offset  int64    // the number of bytes read from the input
+	start   position // the position of the start of the current statement
+	lines   lineScanner
+	r       lineReader // the line holding the current statement
+	buf     bytes.Buffer
+	err     error
+	q       *Quad // the quad being read, which is quad unless reading using NextInto
+	quad    Quad
+	started time.Time // the time of the first read, for Stats
+
+	follow       bool
+	pollInterval time.Duration
+	charset      Charset
+	ctx          context.Context
+
+	readAhead     int // the number of buffers filled ahead of the parser, none if zero
+	readAheadSize int // the size of each buffer filled ahead of the parser
+
+	onProgress    func(ReaderStats) // called after every progressEvery quads returned, may be nil
+	progressEvery int
+
+	stringDatatype  bool   // whether plain literals are given the xsd:string datatype
+	rejectBOM       bool   // whether a leading byte order mark is an error
+	strictEOL       bool   // whether lone carriage returns and mixed line endings are errors
+	truncated       bool   // whether a final statement cut short by the end of the input is accepted
+	rdf11           bool   // whether syntax introduced after RDF 1.1 is rejected
+	decodeLiterals  bool   // whether the values of literal objects are decoded into value
+	escapedLiterals bool   // whether escapes in the values of literals are left in place
+	value           any    // the decoded value of the object of the current quad
+	base            string // the IRI against which relative IRIs are resolved, if any
+	allowRelative   bool   // whether relative IRIs that cannot be resolved are accepted
+	strictIRIs      bool   // whether IRIs are checked against the grammar of RFC 3987
+
+	maxStatement int  // the maximum length of a statement in bytes, unlimited if zero
+	maxLine      int  // the maximum length of a line in bytes, as set by lineLimit
+	maxLiteral   int  // the maximum length of a literal value in bytes, unlimited if zero
+	maxIRI       int  // the maximum length of an IRI in bytes, unlimited if zero
+	maxQuads     int  // the maximum number of quads returned, unlimited if zero
+	depth        int  // the nesting depth of the quoted triple being read
+	discard      bool // whether terms are checked for syntax errors without being constructed
+	shared       bool // whether the strings of terms may share memory with the input
+	pooled       bool // whether the Reader was obtained from GetReader
+	nquads       int  // the number of quads returned
+	limitReached bool
+	held         *Quad // a quad to be returned again by the next call to Next, may be nil
+	lineBytes    int   // the number of bytes read from the current line
+
+	skipInvalid bool                         // whether statements with syntax errors are skipped
+	onInvalid   func(line []byte, err error) // called for each statement skipped, may be nil
+	lastRune    rune                         // the last rune read, or zero after a rune is unread
+	collect     bool                         // whether errors of skipped statements are recorded in errs
+	maxErrors   int                          // the maximum number of errors recorded, unlimited if zero
+	errs        []error
+	skipped     int64 // the number of statements skipped
+
+	graphFilter func(g rdf.Term) bool // reports whether quads in a graph are returned, may be nil
+	dedup       *recentQuads          // if not nil, used to suppress recently returned duplicates
+	subjects    map[string]bool       // the subject IRIs of the quads returned, all if nil
+	predicates  map[string]bool       // the predicate IRIs of the quads returned, all if nil
+	filtered    bool                  // whether the current statement was discarded by a filter
+
+	mapBlank  func(label string) string   // applied to each blank node label read, may be nil
+	onComment func(line int, text string) // called for each comment read, may be nil
+	onWarning func(Warning)               // called for each deviation from good practice, may be nil
+	interner  *interner                   // if not nil, used to share the strings of IRIs and language tags
+	vocab     map[string]string           // the strings of the predicate and datatype IRIs read, if not interning
+	predicate bool                        // whether the term being read is a predicate
+	comment   []byte                      // the text of the comment being read
+
+	capture  bool   // whether the source bytes of each statement are recorded in raw
+	raw      []byte // the source bytes of the current statement
+	lastSize int    // the number of bytes in the last rune read, for unreading from raw
 }
 
 // A Quad consists of a subject, predicate, object and graph
@@ -71,17 +147,167 @@ type Quad struct {
 	G rdf.Term
 }
 
+// String returns the N-Quads serialization of q, without a terminating newline.
 func (q Quad) String() string {
-	if q.G.Kind == rdf.UnknownTerm {
-		return fmt.Sprintf("%s %s %s .", q.S.String(), q.P.String(), q.O.String())
+	return string(AppendQuad(nil, q))
+}
+
+// An Option configures optional behaviour of a Reader.
+type Option func(*Reader)
+
+// defaultPollInterval is the interval used by WithFollow when a non-positive interval is supplied.
+const defaultPollInterval = time.Second
+
+// WithFollow configures the Reader to wait for more data when the end of the input is reached instead of
+// stopping, in the manner of tail -f. The underlying reader is polled for new data every pollInterval. This
+// is needed when reading from named pipes or files that are being appended to. Next will block until a
+// complete quad can be read or an error other than io.EOF is encountered.
+func WithFollow(pollInterval time.Duration) Option {
+	return func(r *Reader) {
+		if pollInterval <= 0 {
+			pollInterval = defaultPollInterval
+		}
+		r.follow = true
+		r.pollInterval = pollInterval
+	}
+}
+
+// WithStringDatatype configures the Reader to give the datatype xsd:string to literals that have neither
+// a datatype nor a language tag. Such literals are equivalent in RDF 1.1, so this only affects how the
+// literal is represented, making plain literals compare equal to the same literal explicitly typed as
+// xsd:string.
+func WithStringDatatype() Option {
+	return func(r *Reader) {
+		r.stringDatatype = true
 	}
-	return fmt.Sprintf("%s %s %s %s .", q.S.String(), q.P.String(), q.O.String(), q.G.String())
 }
 
-// NewReader returns a new Reader that reads from r.
-func NewReader(r io.Reader) *Reader {
-	return &Reader{
-		r: bufio.NewReader(r),
+// NewReader returns a new Reader that reads from r, configured using the supplied options.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	return NewReaderSize(r, 0, opts...)
+}
+
+// NewReaderSize returns a new Reader that reads from r using a buffer of at least size bytes, configured
+// using the supplied options. A size that is not positive selects the default size used by NewReader, and
+// a size smaller than the minimum permitted by the bufio package is increased to that minimum.
+func NewReaderSize(r io.Reader, size int, opts ...Option) *Reader {
+	nr := newReader(opts)
+	r = nr.source(r)
+	if size > 0 {
+		nr.lines.r = bufio.NewReaderSize(r, size)
+	} else {
+		nr.lines.r = bufio.NewReader(r)
+	}
+	if nr.maxLine > 0 {
+		// keep enough of a long line to find where it exceeds the limit
+		nr.lines.max = nr.maxLine + utf8.UTFMax
+	}
+	return nr
+}
+
+// source returns src wrapped to apply the options of r that change how its input is read.
+func (r *Reader) source(src io.Reader) io.Reader {
+	if r.follow {
+		src = &followReader{r: src, pollInterval: r.pollInterval, ctx: r.ctx}
+	}
+	if r.readAhead > 0 {
+		src = newReadAhead(src, r.readAhead, r.readAheadSize)
+	}
+	if r.charset != UTF8 {
+		src = newCharsetReader(src, r.charset)
+	}
+	if r.ctx != nil {
+		src = &contextReader{r: src, ctx: r.ctx}
+	}
+	return src
+}
+
+// Reset discards any buffered input and the state of the current read, and switches r to read from src.
+// The options r was configured with are retained, along with its buffers, so a Reader can be reused to read
+// many inputs without allocating. Options that remember quads between statements, such as WithDedup, start
+// afresh. A Reader created by NewBytesReader reads from src as though created by NewReader.
+func (r *Reader) Reset(src io.Reader) {
+	src = r.source(src)
+	if r.lines.r == nil {
+		r.lines.r = bufio.NewReader(src)
+	} else {
+		r.lines.r.Reset(src)
+	}
+	r.lines = lineScanner{r: r.lines.r, max: r.lines.max, long: r.lines.long[:0]}
+	r.r.reset(nil)
+	r.shared = false
+
+	r.line = 1
+	r.column = -1
+	r.newline = false
+	r.cr = false
+	r.crlf = false
+	r.eol = ""
+	r.offset = 0
+	r.start = position{}
+	r.buf.Reset()
+	r.err = nil
+	r.q = &r.quad
+	r.quad = Quad{}
+	r.value = nil
+	r.depth = 0
+	r.discard = false
+	r.nquads = 0
+	r.skipped = 0
+	r.started = time.Time{}
+	r.limitReached = false
+	r.held = nil
+	r.lineBytes = 0
+	r.lastRune = 0
+	r.errs = nil
+	r.filtered = false
+	r.comment = r.comment[:0]
+	r.raw = r.raw[:0]
+	r.lastSize = 0
+	if r.dedup != nil {
+		r.dedup.reset()
+	}
+}
+
+// newReader returns a new Reader with no input, configured using the supplied options.
+func newReader(opts []Option) *Reader {
+	r := &Reader{line: 1, column: -1}
+	r.q = &r.quad
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.maxLine = r.lineLimit()
+	return r
+}
+
+// followReader is an io.Reader that retries reads from an underlying reader that has reached
+// the end of its input, waiting pollInterval between attempts.
+type followReader struct {
+	r            io.Reader
+	pollInterval time.Duration
+	ctx          context.Context // if not nil, stops waiting when done
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		if err == io.EOF {
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+		if f.ctx == nil {
+			time.Sleep(f.pollInterval)
+			continue
+		}
+		t := time.NewTimer(f.pollInterval)
+		select {
+		case <-f.ctx.Done():
+			t.Stop()
+			return 0, f.ctx.Err()
+		case <-t.C:
+		}
 	}
 }
 
@@ -101,23 +327,108 @@ func (r *Reader) Err() error {
 
 // Quad returns the last quad read
 func (r *Reader) Quad() Quad {
-	return r.q
+	return *r.q
 }
 
 // Next attempts to read the next quad from the underlying reader. It returns false if no quad could be read which
-// may indicate an error has occurred or the end of the input stream has been reached.
+// may indicate an error has occurred, the end of the input stream has been reached or the limit set by
+// WithMaxQuads has been reached.
 func (r *Reader) Next() bool {
+	r.q = &r.quad
+	return r.advance()
+}
+
+// NextInto reads the next quad like Next, but stores it in q rather than in storage owned by the Reader,
+// so that a loop that examines each quad in turn can avoid copying it. Quad returns the contents of q until
+// Next or NextInto is called again. If NextInto returns false the contents of q are unspecified.
+func (r *Reader) NextInto(q *Quad) bool {
+	r.q = q
+	return r.advance()
+}
+
+// Decode reads the next quad into q, in the manner of json.Decoder. It returns io.EOF once the end of the
+// input or the limit set by WithMaxQuads has been reached, or the error returned by Err if reading stopped
+// because of an error. If Decode returns an error the contents of q are unspecified.
+func (r *Reader) Decode(q *Quad) error {
+	if r.NextInto(q) {
+		return nil
+	}
 	if r.err != nil {
+		return r.err
+	}
+	return io.EOF
+}
+
+// advance reads the next quad into r.q, counting it towards the limit set by WithMaxQuads.
+func (r *Reader) advance() bool {
+	if r.held != nil {
+		*r.q, r.held = *r.held, nil
+		return true
+	}
+	if r.maxQuads > 0 && r.nquads >= r.maxQuads {
+		return r.stopAtLimit()
+	}
+	if !r.next() {
 		return false
 	}
+	r.nquads++
+	if r.onProgress != nil && r.nquads%r.progressEvery == 0 {
+		r.onProgress(r.Stats())
+	}
+	return true
+}
 
-	r.q = Quad{}
-	r.line++
-	r.column = -1
+// next reads the next quad that is not skipped or rejected by a filter.
+func (r *Reader) next() bool {
+	for {
+		if r.readStatement() {
+			if r.accept() {
+				return true
+			}
+			continue
+		}
+		if !r.skipInvalid || !r.skip() {
+			return false
+		}
+	}
+}
+
+// readStatement reads the next statement, returning false if no quad could be read. A statement rejected
+// by the subject or predicate filters is discarded without being parsed further, which accept reports.
+func (r *Reader) readStatement() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.started.IsZero() {
+		r.started = time.Now()
+	}
+	if r.ctx != nil {
+		if r.err = r.ctx.Err(); r.err != nil {
+			return false
+		}
+	}
+
+	*r.q = Quad{}
+	r.value = nil
 
 	var err error
 	r1 := '\n'
-	for r1 == '\n' {
+	for isEOL(r1) {
+		if r.r.Buffered() == 0 {
+			if err := r.nextLine(); err != nil {
+				if err != io.EOF {
+					r.err = err
+				}
+				return false
+			}
+		}
+		if r.offset == 0 {
+			if r.err = r.skipBOM(); r.err != nil {
+				return false
+			}
+		}
+		r.raw = r.raw[:0]
+		r.lineBytes = 0
 		r1, err = r.skipWhitespace()
 		if err != nil {
 			if err == io.EOF {
@@ -139,34 +450,41 @@ func (r *Reader) Next() bool {
 		}
 	}
 
-	if err := r.r.UnreadRune(); err != nil {
+	if err := r.unreadRune(); err != nil {
 		r.err = err
 		return false
 	}
+	r.start = position{line: r.line, column: r.column + 1, offset: r.offset}
 
 	// Subject
-	term, err := r.parseIriOrBlankNode()
+	term, err := r.parseIriOrBlankNode(true)
 	if err != nil {
 		r.err = err
 		return false
 	}
-	if term.Kind == rdf.IRITerm && !isAbsoluteIRI(term.Value) {
-		r.err = r.wrap(ErrRelativeIRI)
+	if term, r.err = r.checkTerm(term); r.err != nil {
 		return false
 	}
 	r.q.S = term
+	if r.subjects != nil && !r.discard && (term.Kind != rdf.IRITerm || !r.subjects[term.Value]) {
+		return r.reject()
+	}
 
 	// Property
-	term, err = r.parseIriOrBlankNode()
+	r.predicate = true
+	term, err = r.parseIriOrBlankNode(false)
+	r.predicate = false
 	if err != nil {
 		r.err = err
 		return false
 	}
-	if term.Kind == rdf.IRITerm && !isAbsoluteIRI(term.Value) {
-		r.err = r.wrap(ErrRelativeIRI)
+	if term, r.err = r.checkTerm(term); r.err != nil {
 		return false
 	}
 	r.q.P = term
+	if r.predicates != nil && !r.discard && !r.predicates[term.Value] {
+		return r.reject()
+	}
 
 	// Object
 	term, err = r.parseAnyTerm()
@@ -174,14 +492,13 @@ func (r *Reader) Next() bool {
 		r.err = err
 		return false
 	}
-	if term.Kind == rdf.IRITerm && !isAbsoluteIRI(term.Value) {
-		r.err = r.wrap(ErrRelativeIRI)
-		return false
-	} else if term.Kind == rdf.LiteralTerm && term.Datatype != "" && !isAbsoluteIRI(term.Datatype) {
-		r.err = r.wrap(ErrRelativeIRI)
+	if term, r.err = r.checkTerm(term); r.err != nil {
 		return false
 	}
 	r.q.O = term
+	if r.decodeLiterals {
+		r.value, _ = DecodeLiteral(term)
+	}
 
 	// Graph or end
 	end, term, err := r.parseIriOrBlankNodeOrEndTriple()
@@ -195,6 +512,9 @@ func (r *Reader) Next() bool {
 		return r.err == nil
 	}
 
+	if term, r.err = r.checkTerm(term); r.err != nil {
+		return false
+	}
 	r.q.G = term
 	err = r.readEndQuad()
 	if err != nil {
@@ -205,41 +525,163 @@ func (r *Reader) Next() bool {
 	return r.err == nil
 }
 
-// readRune reads one rune from r, folding \r\n to \n and keeping track
-// of how far into the line we have read.  r.column will point to the start
-// of this rune, not the end of this rune.
-func (r *Reader) readRune() (rune, error) {
-	r1, _, err := r.r.ReadRune()
-
-	// Handle \r\n here.  We make the simplifying assumption that
-	// anytime \r is followed by \n that it can be folded to \n.
-	// We will not detect files which contain both \r\n and bare \n.
-	if r1 == '\r' {
-		r1, _, err = r.r.ReadRune()
-		if err == nil {
-			if r1 != '\n' {
-				if err := r.r.UnreadRune(); err != nil {
-					return r1, err
-				}
-				r1 = '\r'
-			}
+// nextLine reads the next line of the input, which holds the next statement or is blank or a comment.
+func (r *Reader) nextLine() error {
+	line, err := r.lines.scan()
+	if err != nil {
+		return err
+	}
+	r.setLine(line, r.lines.n, r.lines.offset)
+	return nil
+}
+
+// setLine sets the line to be parsed, which is line number n of the input and starts at the given offset.
+func (r *Reader) setLine(line []byte, n int, offset int64) {
+	r.r.reset(line)
+	r.line = n
+	r.column = -1
+	r.offset = offset
+	r.newline = false
+	r.cr = false
+	r.crlf = false
+}
+
+// checkTerm returns term after applying the checks and transformations configured for r to the IRIs and
+// literals it contains.
+func (r *Reader) checkTerm(term rdf.Term) (rdf.Term, error) {
+	if r.discard {
+		return term, nil
+	}
+	var err error
+	switch term.Kind {
+	case rdf.IRITerm:
+		term.Value, err = r.checkIRI(term.Value)
+	case rdf.LiteralTerm, EscapedLiteralTerm:
+		if term.Datatype != "" {
+			term.Datatype, err = r.checkIRI(term.Datatype)
+		} else if r.stringDatatype && term.Language == "" {
+			term.Datatype = xsdString
 		}
 	}
+	return term, err
+}
+
+// text returns the contents of r.buf as a string, or the empty string if terms are being discarded.
+func (r *Reader) text() string {
+	if r.discard {
+		return ""
+	}
+	return r.buf.String()
+}
+
+// readRune reads one rune from r, keeping track of how far into the line
+// we have read. A line ends at \n, \r\n or a lone \r; the \n of a \r\n
+// pair is returned but does not start another line. r.column will point
+// to the start of this rune, not the end of this rune.
+func (r *Reader) readRune() (rune, error) {
+	if r.maxLine > 0 && r.lineBytes > r.maxLine {
+		return 0, r.wrap(ErrStatementTooLong)
+	}
+	r1, err := r.readRawRune()
+	r.crlf = r1 == '\n' && r.cr && err == nil
+	if r.newline && !r.crlf && err == nil {
+		r.line++
+		r.column = -1
+	}
 	r.column++
+	r.lastRune = r1
+	r.cr = r1 == '\r' && err == nil
+	r.newline = r1 == '\n' || r.cr
+	if r.strictEOL && err == nil && isEOL(r1) {
+		if err := r.checkEOL(r1); err != nil {
+			return r1, err
+		}
+	}
 	return r1, err
 }
 
 // unreadRune puts the last rune read from r back.
 func (r *Reader) unreadRune() error {
-	if err := r.r.UnreadRune(); err != nil {
+	if err := r.unreadRawRune(); err != nil {
 		return err
 	}
 	r.column--
+	r.lastRune = 0
+	// only the \n of a \r\n pair leaves a line ending pending
+	r.newline = r.crlf
+	r.cr = r.crlf
+	r.crlf = false
+	return nil
+}
+
+// readRawRune reads one rune from the underlying reader, recording its bytes in r.raw
+// if capture is enabled.
+func (r *Reader) readRawRune() (rune, error) {
+	if !r.capture {
+		// Most input is ASCII, which needs no decoding
+		b, err := r.r.ReadByte()
+		if err != nil {
+			r.lastSize = 0
+			return 0, err
+		}
+		r1, size := rune(b), 1
+		if b >= utf8.RuneSelf {
+			r.r.UnreadByte()
+			r1, size, err = r.r.ReadRune()
+		}
+		r.lineBytes += size
+		r.offset += int64(size)
+		r.lastSize = size
+		return r1, err
+	}
+
+	// Keep the first byte so that an invalid UTF-8 sequence can be recorded verbatim
+	var first byte
+	if b, err := r.r.Peek(1); err == nil {
+		first = b[0]
+	}
+	r1, size, err := r.r.ReadRune()
+	if err != nil {
+		return r1, err
+	}
+	if r1 == utf8.RuneError && size == 1 {
+		r.raw = append(r.raw, first)
+	} else {
+		r.raw = utf8.AppendRune(r.raw, r1)
+	}
+	r.lineBytes += size
+	r.offset += int64(size)
+	r.lastSize = size
+	return r1, nil
+}
+
+// unreadRawRune puts the last rune read from the underlying reader back, removing its
+// bytes from r.raw if capture is enabled.
+func (r *Reader) unreadRawRune() error {
+	var err error
+	if r.lastSize == 1 {
+		err = r.r.UnreadByte()
+	} else {
+		err = r.r.UnreadRune()
+	}
+	if err != nil {
+		return err
+	}
+	r.lineBytes -= r.lastSize
+	r.offset -= int64(r.lastSize)
+	if r.capture {
+		r.raw = r.raw[:len(r.raw)-r.lastSize]
+	}
 	return nil
 }
 
 func (r *Reader) parseIRI() (term rdf.Term, err error) {
+	start := r.r.i
 	for {
+		if r.maxIRI > 0 && r.buf.Len() > r.maxIRI {
+			return term, r.wrap(ErrIRITooLong)
+		}
+		r.scanASCII(r.maxIRI)
 		r1, err := r.readRune()
 		if err != nil {
 			if err == io.EOF {
@@ -254,7 +696,10 @@ func (r *Reader) parseIRI() (term rdf.Term, err error) {
 			if r.buf.Len() == 0 {
 				return term, r.wrap(ErrUnexpectedCharacter)
 			}
-			return rdf.IRI(r.buf.String()), nil
+			if r.predicate {
+				return rdf.IRI(r.vocabSpan(start, r.r.i-1)), nil
+			}
+			return rdf.IRI(r.internedSpan(start, r.r.i-1)), nil
 
 		} else if r1 == '\\' {
 			r1, err = r.readRune()
@@ -293,6 +738,9 @@ func (r *Reader) parseIRI() (term rdf.Term, err error) {
 					}
 
 				}
+				if r.onWarning != nil && !needsIRIEscape(codepoint) {
+					r.warn(ErrNonCanonicalEscape)
+				}
 				r.buf.WriteRune(codepoint)
 			default:
 				return term, r.wrap(ErrUnexpectedCharacter)
@@ -318,6 +766,7 @@ func (r *Reader) parseBlankNode() (rdf.Term, error) {
 		return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
 	}
 
+	start := r.r.i
 	r1, err = r.readRune()
 	if err != nil {
 		if err == io.EOF {
@@ -334,6 +783,9 @@ func (r *Reader) parseBlankNode() (rdf.Term, error) {
 		r1, err = r.readRune()
 		if err != nil {
 			if err == io.EOF {
+				if r.truncated {
+					return r.blankNode(start), nil
+				}
 				return rdf.Term{}, r.wrap(ErrUnexpectedEOF)
 			}
 			return rdf.Term{}, err
@@ -342,22 +794,27 @@ func (r *Reader) parseBlankNode() (rdf.Term, error) {
 		if isPnChars(r1) {
 			r.buf.WriteRune(r1)
 		} else if isSpace(r1) {
-			return rdf.Blank(r.buf.String()), nil
+			return r.blankNode(start), nil
+		} else if r1 == '>' {
+			// the end of a quoted triple
+			if err := r.unreadRune(); err != nil {
+				return rdf.Term{}, err
+			}
+			return r.blankNode(start), nil
 		} else if r1 == '.' {
-			err := r.unreadRune()
-			if err != nil {
-				return rdf.Term{}, nil
+			if err := r.unreadRune(); err != nil {
+				return rdf.Term{}, err
 			}
 			// period is not allowed at the end of a blank node
 			next, err := r.r.Peek(2)
 			if err == io.EOF {
 				// period is the last character in the file so must be a triple terminator
-				return rdf.Blank(r.buf.String()), nil
+				return r.blankNode(start), nil
 			}
 
-			if next[1] == ' ' || next[1] == '\t' || next[1] == '\n' || next[1] == '\r' {
+			if next[1] == ' ' || next[1] == '\t' || next[1] == '\n' || next[1] == '\r' || next[1] == '>' {
 				// period is not part of the blank node
-				return rdf.Blank(r.buf.String()), nil
+				return r.blankNode(start), nil
 			}
 
 			if _, err := r.readRune(); err != nil {
@@ -373,7 +830,12 @@ func (r *Reader) parseBlankNode() (rdf.Term, error) {
 }
 
 func (r *Reader) parseLiteral() (term rdf.Term, err error) {
+	start := r.r.i
 	for {
+		if r.maxLiteral > 0 && r.buf.Len() > r.maxLiteral {
+			return term, r.wrap(ErrLiteralTooLong)
+		}
+		r.scanLiteral(r.maxLiteral)
 		r1, err := r.readRune()
 		if err != nil {
 			if err == io.EOF {
@@ -383,9 +845,13 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 		}
 		switch r1 {
 		case '"':
+			end := r.r.i - 1
 			r1, err = r.readRune()
 			if err != nil {
 				if err == io.EOF {
+					if r.truncated {
+						return rdf.Literal(r.spanText(start, end)), nil
+					}
 					return term, r.wrap(ErrUnexpectedEOF)
 				}
 				return term, err
@@ -393,52 +859,18 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 
 			switch r1 {
 
-			case '.', ' ', '\t':
+			case '.', ' ', '\t', '>':
 				if err := r.unreadRune(); err != nil {
 					return term, r.wrap(err)
 				}
-				return rdf.Literal(r.buf.String()), nil
+				return rdf.Literal(r.spanText(start, end)), nil
 			case '@':
-				value := r.buf.String()
+				value := r.spanText(start, end)
 				r.buf.Reset()
 
-				major := true
-				for {
-					r1, err = r.readRune()
-					if err != nil {
-						if err == io.EOF {
-							return term, r.wrap(ErrUnexpectedEOF)
-						}
-						return term, err
-					}
-					if r1 == '.' || isSpace(r1) {
-						if r.buf.Len() == 0 {
-							return term, r.wrap(ErrUnexpectedCharacter)
-						}
-						if err := r.unreadRune(); err != nil {
-							return term, r.wrap(err)
-						}
-						return rdf.LiteralWithLanguage(value, r.buf.String()), nil
-					}
-					if major {
-						if isAlpha(r1) {
-							r.buf.WriteRune(r1)
-						} else if r1 == '-' {
-							r.buf.WriteRune(r1)
-							major = false // switch to language subtype
-						} else {
-							return term, r.wrap(ErrUnexpectedCharacter)
-						}
-					} else {
-						if isAlpha(r1) || isNumeral(r1) {
-							r.buf.WriteRune(r1)
-						} else {
-							return term, r.wrap(ErrUnexpectedCharacter)
-						}
-					}
-				}
+				return r.parseLanguageTag(value)
 			case '^':
-				value := r.buf.String()
+				value := r.spanText(start, end)
 				r.buf.Reset()
 
 				r1, err = r.readRune()
@@ -464,7 +896,11 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 				}
 
 				// Read an IRI
+				start := r.r.i
 				for {
+					if r.maxIRI > 0 && r.buf.Len() > r.maxIRI {
+						return term, r.wrap(ErrIRITooLong)
+					}
 					r1, err = r.readRune()
 					if err != nil {
 						if err == io.EOF {
@@ -476,7 +912,7 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 						if r.buf.Len() == 0 {
 							return term, r.wrap(ErrUnexpectedCharacter)
 						}
-						return rdf.LiteralWithDatatype(value, r.buf.String()), nil
+						return rdf.LiteralWithDatatype(value, r.vocabSpan(start, r.r.i-1)), nil
 					} else if r1 < 0x20 || r1 > 0x7E || r1 == ' ' || r1 == '<' || r1 == '"' {
 						return term, r.wrap(ErrUnexpectedCharacter)
 					}
@@ -487,6 +923,7 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 			return term, r.wrap(ErrUnexpectedCharacter)
 
 		case '\\':
+			escape := r.r.i - 1
 			r1, err = r.readRune()
 			if err != nil {
 				if err == io.EOF {
@@ -535,25 +972,121 @@ func (r *Reader) parseLiteral() (term rdf.Term, err error) {
 					}
 
 				}
+				if r.onWarning != nil && !needsCodepointEscape(codepoint) {
+					r.warn(ErrNonCanonicalEscape)
+				}
 				r1 = codepoint
 
 			default:
 				return term, r.wrap(ErrUnexpectedCharacter)
 			}
+			if r.escapedLiterals {
+				r.buf.Write(r.r.b[escape:r.r.i])
+				continue
+			}
+		}
+		r.buf.WriteRune(r1)
+	}
+}
+
+// These are the parts of a language tag read by parseLanguageTag.
+const (
+	langPrimary        = iota // the primary language subtag
+	langSubtagStart           // the start of a subtag following a '-'
+	langSubtag                // a subtag following the primary subtag
+	langDirectionStart        // the start of a base direction following "--"
+	langDirection             // a base direction
+)
+
+// parseLanguageTag parses the language tag of a literal with the given value after the '@', including any
+// base direction.
+func (r *Reader) parseLanguageTag(value string) (rdf.Term, error) {
+	start := r.r.i
+	part := langPrimary
+	for {
+		r1, err := r.readRune()
+		if err != nil {
+			if err != io.EOF {
+				return rdf.Term{}, err
+			}
+			if !r.truncated {
+				return rdf.Term{}, r.wrap(ErrUnexpectedEOF)
+			}
+			r1 = ' ' // accept the truncated statement if the tag is complete
+		} else if r1 == '.' || r1 == '>' || isSpace(r1) {
+			if err := r.unreadRune(); err != nil {
+				return rdf.Term{}, r.wrap(err)
+			}
+		}
+
+		switch {
+		case r1 == '.' || r1 == '>' || isSpace(r1):
+			if r.buf.Len() == 0 || part == langSubtagStart || part == langDirectionStart {
+				if err == io.EOF {
+					return rdf.Term{}, r.wrap(ErrUnexpectedEOF)
+				}
+				return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+			}
+			lang, dir, _ := bytes.Cut(r.buf.Bytes(), []byte("--"))
+			if part == langDirection && string(dir) != DirectionLTR && string(dir) != DirectionRTL {
+				return rdf.Term{}, r.wrap(ErrInvalidDirection)
+			}
+			if r.onWarning != nil && !isWellFormedLanguageTag(string(lang)) {
+				r.warn(ErrMalformedLanguageTag)
+			}
+			return rdf.LiteralWithLanguage(value, r.internedSpan(start, start+r.buf.Len())), nil
+		case r1 == '-':
+			switch part {
+			case langPrimary, langSubtag:
+				if r.buf.Len() == 0 {
+					return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+				}
+				part = langSubtagStart
+			case langSubtagStart:
+				if r.rdf11 {
+					return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+				}
+				part = langDirectionStart
+			default:
+				return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+			}
+		case isAlpha(r1):
+			switch part {
+			case langSubtagStart:
+				part = langSubtag
+			case langDirectionStart:
+				part = langDirection
+			}
+		case isNumeral(r1):
+			switch part {
+			case langSubtagStart, langSubtag:
+				part = langSubtag
+			default:
+				return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+			}
+		default:
+			return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
 		}
 		r.buf.WriteRune(r1)
 	}
 }
 
-func (r *Reader) parseIriOrBlankNode() (term rdf.Term, err error) {
+// parseIriOrBlankNode parses an IRI or blank node, or a quoted triple if quoted is true.
+func (r *Reader) parseIriOrBlankNode(quoted bool) (term rdf.Term, err error) {
 	r.buf.Reset()
 
 	r1, err := r.skipWhitespace()
 	if err != nil {
+		if err == io.EOF {
+			return term, r.wrap(ErrUnexpectedEOF)
+		}
 		return term, err
 	}
 	switch r1 {
 	case '<':
+		if quoted {
+			return r.parseIRIOrQuotedTriple()
+		}
 		// Read an IRI
 		return r.parseIRI()
 	case '_':
@@ -571,18 +1104,28 @@ func (r *Reader) parseAnyTerm() (term rdf.Term, err error) {
 
 	r1, err := r.skipWhitespace()
 	if err != nil {
+		if err == io.EOF {
+			return term, r.wrap(ErrUnexpectedEOF)
+		}
 		return term, err
 	}
 	switch r1 {
 	case '<':
-		// Read an IRI
-		return r.parseIRI()
+		return r.parseIRIOrQuotedTriple()
 	case '_':
 		// Read a blank node
 		return r.parseBlankNode()
 	case '"':
 		// Read a literal
-		return r.parseLiteral()
+		term, err = r.parseLiteral()
+		if err == nil && r.escapedLiterals && strings.IndexByte(term.Value, '\\') >= 0 {
+			term.Kind = EscapedLiteralTerm
+			if r.depth > 0 {
+				// the values of quoted triples are always written in their canonical form
+				return UnescapeTerm(term)
+			}
+		}
+		return term, err
 	default:
 		// TODO: raise error, unexpected character
 		return term, r.wrap(ErrUnexpectedCharacter)
@@ -590,11 +1133,41 @@ func (r *Reader) parseAnyTerm() (term rdf.Term, err error) {
 	}
 }
 
+// ParseTerm parses s as a single IRI, blank node or literal term in N-Quads syntax, such as
+// <http://example/s>, _:b1 or "chat"@fr. Surrounding whitespace is ignored. As in a statement, IRIs must be
+// absolute.
+func ParseTerm(s string) (rdf.Term, error) {
+	// Append space to input to act as delimiter
+	r := newStatementReader([]byte(s + " "))
+
+	term, err := r.parseAnyTerm()
+	if err != nil {
+		return rdf.Term{}, err
+	}
+	if term, err = r.checkTerm(term); err != nil {
+		return rdf.Term{}, err
+	}
+
+	if _, err := r.skipWhitespace(); err != io.EOF {
+		if err != nil {
+			return rdf.Term{}, err
+		}
+		return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+	}
+	return term, nil
+}
+
 func (r *Reader) parseIriOrBlankNodeOrEndTriple() (bool, rdf.Term, error) {
 	r.buf.Reset()
 
 	r1, err := r.skipWhitespace()
 	if err != nil {
+		if err == io.EOF {
+			if r.truncated {
+				return true, rdf.Term{}, nil
+			}
+			return false, rdf.Term{}, r.wrap(ErrUnterminatedQuad)
+		}
 		return false, rdf.Term{}, err
 	}
 	switch r1 {
@@ -618,6 +1191,9 @@ func (r *Reader) readEndQuad() (err error) {
 	r1, err := r.skipWhitespace()
 	if err != nil {
 		if err == io.EOF {
+			if r.truncated {
+				return nil
+			}
 			return r.wrap(ErrUnterminatedQuad)
 		}
 		return err
@@ -647,21 +1223,21 @@ func (r *Reader) skipWhitespace() (r1 rune, err error) {
 }
 
 func (r *Reader) skipRestOfLine() (r1 rune, err error) {
+	if r.onComment != nil {
+		return r.readComment()
+	}
 	r1, err = r.readRune()
 	if err != nil {
 		return r1, err
 	}
 
-	for r1 != '\n' {
+	for !isEOL(r1) {
 		r1, err = r.readRune()
 		if err != nil {
 			return r1, err
 		}
 	}
-	r.line++
-	r.column = 0
-
-	// r1 is now the newline
+	// r1 is now the line terminator
 	return r1, nil
 }
 
@@ -675,21 +1251,21 @@ func (r *Reader) expectCommentOrEndOfLine() error {
 	}
 
 	if r1 == '#' {
-		_, err = r.skipRestOfLine()
+		r1, err = r.skipRestOfLine()
 		if err != nil {
 			if err == io.EOF {
 				return nil
 			}
 			return err
 		}
-		return nil
+		return r.endLine(r1)
 	}
 
-	if r1 != '\n' {
+	if !isEOL(r1) {
 		return r.wrap(ErrUnexpectedCharacter)
 	}
 
-	return nil
+	return r.endLine(r1)
 }
 
 func isPnCharsBase(r rune) bool {
@@ -737,7 +1313,9 @@ func isPnCharsBase(r rune) bool {
 	return false
 }
 
-func isPnCharsU(r rune) bool {
+// pnCharsU reports whether r is in the PN_CHARS_U class of the grammar. isPnCharsU gives the same result
+// using a lookup table.
+func pnCharsU(r rune) bool {
 	if r == '_' || r == ':' {
 		return true
 	}
@@ -747,14 +1325,16 @@ func isPnCharsU(r rune) bool {
 	return false
 }
 
-func isPnChars(r rune) bool {
+// pnChars reports whether r is in the PN_CHARS class of the grammar. isPnChars gives the same result
+// using a lookup table.
+func pnChars(r rune) bool {
 	if r == '-' || r == 0x00B7 {
 		return true
 	}
 	if isNumeral(r) {
 		return true
 	}
-	if isPnCharsU(r) {
+	if pnCharsU(r) {
 		return true
 	}
 	if r >= 0x0300 && r <= 0x036F {
@@ -6,11 +6,14 @@
 package nquads
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/iand/gordf"
 )
@@ -494,6 +497,21 @@ var negativeSyntaxCases = []struct {
 		inline: "<http://example.org/graph1> <http://example.org/resource1> <http://example.org/property> <http://example.org/resource2> ",
 		err:    ErrUnterminatedQuad,
 	},
+	{
+		name:   "no-terminating-dot-triple",
+		inline: "<http://example.org/resource1> <http://example.org/property> <http://example.org/resource2>",
+		err:    ErrUnterminatedQuad,
+	},
+	{
+		name:   "missing-object",
+		inline: "<http://example.org/resource1> <http://example.org/property> ",
+		err:    ErrUnexpectedEOF,
+	},
+	{
+		name:   "relative-graph-iri",
+		inline: "<http://example.org/resource1> <http://example.org/property> <http://example.org/resource2> <graph1> .",
+		err:    ErrRelativeIRI,
+	},
 	{
 		name:   "wrong-terminating-character",
 		inline: "<http://example.org/graph1> <http://example.org/resource1> <http://example.org/property> <http://example.org/resource2> ,",
@@ -833,6 +851,84 @@ func TestNegativeSyntaxCases(t *testing.T) {
 	}
 }
 
+func TestTruncatedStatement(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{name: "after subject", input: "<http://example/s>", err: ErrUnexpectedEOF},
+		{name: "after predicate", input: "<http://example/s> <http://example/p> ", err: ErrUnexpectedEOF},
+		{name: "after object", input: "<http://example/s> <http://example/p> <http://example/o> ", err: ErrUnterminatedQuad},
+		{name: "after graph", input: "<http://example/s> <http://example/p> <http://example/o> <http://example/g>", err: ErrUnterminatedQuad},
+		{name: "in quoted triple", input: "<< <http://example/s> <http://example/p> ", err: ErrUnexpectedEOF},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.input))
+			if r.Next() {
+				t.Fatalf("got quad %s, wanted error", r.Quad())
+			}
+			if !errors.Is(r.Err(), tc.err) {
+				t.Errorf("got error %v, wanted %v", r.Err(), tc.err)
+			}
+			var perr *ParseError
+			if !errors.As(r.Err(), &perr) {
+				t.Errorf("got error %v, wanted a ParseError", r.Err())
+			}
+		})
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	testCases := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{
+			input:  "bad",
+			line:   1,
+			column: 0,
+		},
+		{
+			input:  "\n\nbad",
+			line:   3,
+			column: 0,
+		},
+		{
+			input:  "<http://example/s> <http://example/p> \"1\" . # comment\nbad",
+			line:   2,
+			column: 0,
+		},
+		{
+			input:  "# comment\r\n<http://example/s> bad",
+			line:   2,
+			column: 19,
+		},
+		{
+			input:  "<http://example/s> <http://example/p> \"é\" .\n  <http://example/s> <http://example/p> \"é\" bad",
+			line:   2,
+			column: 44,
+		},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(tc.input))
+		for r.Next() {
+		}
+		var perr *ParseError
+		if !errors.As(r.Err(), &perr) {
+			t.Errorf("%q: got error %v, wanted a ParseError", tc.input, r.Err())
+			continue
+		}
+		if perr.Line != tc.line || perr.Column != tc.column {
+			t.Errorf("%q: got error at line %d column %d, wanted line %d column %d", tc.input, perr.Line, perr.Column, tc.line, tc.column)
+		}
+	}
+}
+
 func TestParseIRI(t *testing.T) {
 	testCases := []struct {
 		input string
@@ -868,7 +964,7 @@ func TestParseIRI(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run("", func(t *testing.T) {
 			// Append space to input to act as delimiter
-			nqr := NewReader(strings.NewReader(tc.input + " "))
+			nqr := newStatementReader([]byte(tc.input + " "))
 
 			term, err := nqr.parseIRI()
 			switch {
@@ -930,7 +1026,7 @@ func TestParseBlankNode(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run("", func(t *testing.T) {
 			// Append space to input to act as delimiter
-			nqr := NewReader(strings.NewReader(tc.input + " "))
+			nqr := newStatementReader([]byte(tc.input + " "))
 
 			term, err := nqr.parseBlankNode()
 			switch {
@@ -948,3 +1044,227 @@ func TestParseBlankNode(t *testing.T) {
 		})
 	}
 }
+
+// growingReader is an io.Reader that returns io.EOF until more data is appended to it.
+type growingReader struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (g *growingReader) Read(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Read(p)
+}
+
+func (g *growingReader) WriteString(s string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.buf.WriteString(s)
+}
+
+func TestFollow(t *testing.T) {
+	g := &growingReader{}
+	g.WriteString("<http://example/s> <http://example/p> <http://example/o1> .\n<http://example/s> <http://example/p> ")
+
+	nqr := NewReader(g, WithFollow(time.Millisecond))
+	if !nqr.Next() {
+		t.Fatalf("first quad: missing (err=%v)", nqr.Err())
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		g.WriteString("<http://example/o2> .\n")
+	}()
+
+	if !nqr.Next() {
+		t.Fatalf("second quad: missing (err=%v)", nqr.Err())
+	}
+	if got, want := nqr.Quad().O, rdf.IRI("http://example/o2"); got != want {
+		t.Errorf("got object %q, wanted %q", got.Value, want.Value)
+	}
+}
+
+func TestStringDatatype(t *testing.T) {
+	input := `<http://example/s> <http://example/p> "plain" .
+<http://example/s> <http://example/p> "tagged"@en .
+<http://example/s> <http://example/p> "typed"^^<http://www.w3.org/2001/XMLSchema#string> .
+<http://example/s> <http://example/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`
+	want := []rdf.Term{
+		rdf.LiteralWithDatatype("plain", "http://www.w3.org/2001/XMLSchema#string"),
+		rdf.LiteralWithLanguage("tagged", "en"),
+		rdf.LiteralWithDatatype("typed", "http://www.w3.org/2001/XMLSchema#string"),
+		rdf.LiteralWithDatatype("1", "http://www.w3.org/2001/XMLSchema#integer"),
+	}
+
+	nqr := NewReader(strings.NewReader(input), WithStringDatatype())
+	var got []rdf.Term
+	for nqr.Next() {
+		got = append(got, nqr.Quad().O)
+	}
+	if nqr.Err() != nil {
+		t.Fatalf("got unexpected error %q", nqr.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %#v, wanted %#v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewReaderSize(t *testing.T) {
+	long := strings.Repeat("x", 10000)
+	input := "<http://example/s> <http://example/p> \"" + long + "\" .\n<http://example/s> <http://example/p> \"short\" .\n"
+
+	for _, size := range []int{-1, 0, 1, 16, 64, 1 << 20} {
+		nqr := NewReaderSize(strings.NewReader(input), size)
+		var got []string
+		for nqr.Next() {
+			got = append(got, nqr.Quad().O.Value)
+		}
+		if nqr.Err() != nil {
+			t.Errorf("size %d: got unexpected error %q", size, nqr.Err())
+			continue
+		}
+		if len(got) != 2 || got[0] != long || got[1] != "short" {
+			t.Errorf("size %d: got %d quads with unexpected values", size, len(got))
+		}
+	}
+}
+
+func TestNextInto(t *testing.T) {
+	want, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	nqr := NewReader(strings.NewReader(datasetInput), WithMaxQuads(len(want)-1))
+	var q Quad
+	var got []Quad
+	for i := 0; ; i++ {
+		// Alternate between Next and NextInto
+		if i%2 == 0 {
+			if !nqr.NextInto(&q) {
+				break
+			}
+			if nqr.Quad() != q {
+				t.Errorf("%d: Quad returned %s, wanted %s", i, nqr.Quad(), q)
+			}
+			got = append(got, q)
+			continue
+		}
+		if !nqr.Next() {
+			break
+		}
+		got = append(got, nqr.Quad())
+	}
+	if nqr.Err() != nil {
+		t.Fatalf("got unexpected error %q", nqr.Err())
+	}
+	if len(got) != len(want)-1 {
+		t.Fatalf("got %d quads, wanted %d", len(got), len(want)-1)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %s, wanted %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	first := "<http://example/s2> <http://example/p> \"b\" .\r\n<http://example/s1> <http://example/p> \"a\" .\r\n"
+	testCases := []struct {
+		name string
+		r    *Reader
+		opts []Option
+	}{
+		{name: "default", r: NewReader(strings.NewReader(first))},
+		{name: "dedup", r: NewReader(strings.NewReader(first), WithDedup(10)), opts: []Option{WithDedup(10)}},
+		{name: "max quads", r: NewReader(strings.NewReader(first), WithMaxQuads(1)), opts: []Option{WithMaxQuads(1)}},
+		{name: "bytes reader", r: NewBytesReader([]byte(first))},
+		{name: "error", r: NewReader(strings.NewReader("<http://example/s> bad\n"))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := ReadAll(strings.NewReader(datasetInput), tc.opts...)
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+
+			for tc.r.Next() {
+			}
+			tc.r.Reset(strings.NewReader(datasetInput))
+			var got []Quad
+			for tc.r.Next() {
+				got = append(got, tc.r.Quad())
+			}
+			if tc.r.Err() != nil {
+				t.Fatalf("got unexpected error %q", tc.r.Err())
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("got %s, wanted %s", got[i], want[i])
+				}
+			}
+
+			fresh := NewReader(strings.NewReader(datasetInput), tc.opts...)
+			for fresh.Next() {
+			}
+			gotLine, gotCol, gotOffset := tc.r.Position()
+			wantLine, wantCol, wantOffset := fresh.Position()
+			if gotLine != wantLine || gotCol != wantCol || gotOffset != wantOffset {
+				t.Errorf("got position %d:%d at offset %d, wanted %d:%d at offset %d", gotLine, gotCol, gotOffset, wantLine, wantCol, wantOffset)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		opts  []Option
+		want  int
+		err   error
+	}{
+		{name: "all", input: datasetInput, want: 8, err: io.EOF},
+		{name: "limit", input: datasetInput, opts: []Option{WithMaxQuads(3)}, want: 3, err: io.EOF},
+		{name: "empty", input: "", want: 0, err: io.EOF},
+		{name: "error", input: datasetInput + "<http://example/s> bad\n", want: 8, err: ErrUnexpectedCharacter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want, _ := ReadAll(strings.NewReader(tc.input), tc.opts...)
+			r := NewReader(strings.NewReader(tc.input), tc.opts...)
+			var got []Quad
+			var err error
+			for {
+				var q Quad
+				if err = r.Decode(&q); err != nil {
+					break
+				}
+				got = append(got, q)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("got error %v, wanted %v", err, tc.err)
+			}
+			if len(got) != tc.want {
+				t.Fatalf("got %d quads, wanted %d", len(got), tc.want)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("%d: got %s, wanted %s", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// The size of the batches of lines passed to the workers of a ParallelReader.
+const (
+	parallelBatchLines = 512
+	parallelBatchBytes = 64 << 10
+)
+
+// A ParallelReader reads quads from an N-Quads encoded input like a Reader, but parses the statements on
+// several goroutines. The input is read and split into lines on one goroutine and batches of lines are
+// parsed by a pool of workers, which speeds up reading large inputs on machines with several cores.
+//
+// The options are applied to the parsing of each statement by every worker, so functions passed using
+// options such as WithCommentHandler and WithWarningHandler may be called from several goroutines at the
+// same time. WithDedup and WithMaxQuads apply to the quads returned by Next. WithRawCapture,
+// WithErrorCollection and WithProgress are not supported.
+//
+// Next must be called until it returns false, or Close called, to stop the goroutines used by the
+// ParallelReader.
+type ParallelReader struct {
+	Unordered bool // true to return quads as soon as they are parsed rather than in input order
+
+	src     *Reader // reads lines from the input and applies the options that span statements
+	opts    []Option
+	workers int
+	count   bool // whether the workers only count statements, for CountParallel
+
+	started bool
+	closed  bool
+	stop    chan struct{} // closed to stop the goroutines early
+	once    sync.Once
+	results chan *parallelBatch
+	pending map[int]*parallelBatch // batches parsed ahead of the next to be returned, when ordered
+	seq     int                    // the sequence number of the next batch to be returned, when ordered
+	batch   *parallelBatch         // the batch holding the quads being returned
+	i       int                    // the index of the next quad to be returned from batch
+
+	q      Quad
+	err    error
+	nquads int
+}
+
+// A parallelBatch is a batch of lines to be parsed by a worker of a ParallelReader.
+type parallelBatch struct {
+	seq     int
+	buf     []byte  // the bytes of the lines
+	ends    []int   // the offset in buf of the end of each line
+	offsets []int64 // the offset in the input of the start of each line
+	line    int     // the line number of the first line
+	quads   []Quad  // the quads parsed from the lines
+	n       int     // the number of statements counted, when counting
+	err     error   // the error that stopped parsing or reading the lines, if any
+}
+
+// NewParallelReader returns a new ParallelReader that reads from r, parsing statements on the given number
+// of goroutines and configured using the supplied options. If workers is not positive the value of
+// runtime.GOMAXPROCS is used.
+func NewParallelReader(r io.Reader, workers int, opts ...Option) *ParallelReader {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &ParallelReader{
+		src:     NewReader(r, opts...),
+		opts:    opts,
+		workers: workers,
+	}
+}
+
+// Err returns any error encountered while reading. If Err is non-nil then Next will always return false.
+func (p *ParallelReader) Err() error {
+	return p.err
+}
+
+// Quad returns the last quad read.
+func (p *ParallelReader) Quad() Quad {
+	return p.q
+}
+
+// Next attempts to read the next quad. It returns false if no quad could be read which may indicate an error
+// has occurred, the end of the input has been reached or the limit set by WithMaxQuads has been reached.
+// If Unordered is false, quads are returned in the order of the input and an error is returned after all
+// the quads that precede it. Otherwise quads that follow an error in the input may be returned before it.
+func (p *ParallelReader) Next() bool {
+	if !p.started {
+		p.start()
+	}
+	for p.err == nil && !p.closed {
+		if p.src.maxQuads > 0 && p.nquads >= p.src.maxQuads {
+			p.Close()
+			return false
+		}
+		if p.batch != nil && p.i < len(p.batch.quads) {
+			q := p.batch.quads[p.i]
+			p.i++
+			if p.src.dedup != nil && !p.src.dedup.add(q) {
+				continue
+			}
+			p.q = q
+			p.nquads++
+			return true
+		}
+		if p.batch != nil && p.batch.err != nil {
+			p.err = p.batch.err
+			p.Close()
+			return false
+		}
+		if p.batch = p.receive(); p.batch == nil {
+			return false
+		}
+		p.i = 0
+	}
+	return false
+}
+
+// Close stops the goroutines used by the ParallelReader. Next returns false after Close has been called.
+// Close does not close the underlying reader.
+func (p *ParallelReader) Close() error {
+	p.once.Do(func() {
+		if p.stop != nil {
+			close(p.stop)
+		}
+	})
+	p.closed = true
+	p.batch = nil
+	return nil
+}
+
+// receive returns the next batch of parsed quads, or nil if there are no more.
+func (p *ParallelReader) receive() *parallelBatch {
+	if p.Unordered {
+		return <-p.results
+	}
+	for {
+		if b, ok := p.pending[p.seq]; ok {
+			delete(p.pending, p.seq)
+			p.seq++
+			return b
+		}
+		b, ok := <-p.results
+		if !ok {
+			return nil
+		}
+		p.pending[b.seq] = b
+	}
+}
+
+// start starts the goroutines that read and parse the input.
+func (p *ParallelReader) start() {
+	p.started = true
+	p.stop = make(chan struct{})
+	p.pending = make(map[int]*parallelBatch)
+	p.results = make(chan *parallelBatch, p.workers)
+	work := make(chan *parallelBatch, p.workers)
+
+	go p.scan(work)
+
+	var wg sync.WaitGroup
+	wg.Add(p.workers)
+	for range p.workers {
+		go func() {
+			defer wg.Done()
+			p.parse(work)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.results)
+	}()
+}
+
+// scan splits the input into batches of lines and sends them to work.
+func (p *ParallelReader) scan(work chan<- *parallelBatch) {
+	defer close(work)
+	for seq := 0; ; seq++ {
+		b := &parallelBatch{seq: seq}
+		for len(b.ends) < parallelBatchLines && len(b.buf) < parallelBatchBytes {
+			line, err := p.src.lines.scan()
+			if err != nil {
+				if err != io.EOF {
+					b.err = err
+				}
+				break
+			}
+			if len(b.ends) == 0 {
+				b.line = p.src.lines.n
+			}
+			b.buf = append(b.buf, line...)
+			b.ends = append(b.ends, len(b.buf))
+			b.offsets = append(b.offsets, p.src.lines.offset)
+		}
+		if len(b.ends) == 0 && b.err == nil {
+			return
+		}
+		select {
+		case work <- b:
+		case <-p.stop:
+			return
+		}
+		if len(b.ends) < parallelBatchLines && len(b.buf) < parallelBatchBytes {
+			// the end of the input was reached
+			return
+		}
+	}
+}
+
+// parse parses the batches of lines received from work and sends them to p.results.
+func (p *ParallelReader) parse(work <-chan *parallelBatch) {
+	r := newStatementReader(nil, p.opts...)
+	r.dedup = nil
+	r.maxQuads = 0
+	r.collect = false
+
+	for b := range work {
+		r.err = nil
+		start := 0
+		for i, end := range b.ends {
+			r.setLine(b.buf[start:end], b.line+i, b.offsets[i])
+			if p.count {
+				n, _ := r.skipStatements(math.MaxInt)
+				b.n += n
+			} else {
+				for r.next() {
+					b.quads = append(b.quads, r.Quad())
+				}
+			}
+			if r.err != nil {
+				b.err = r.err
+				break
+			}
+			start = end
+		}
+		select {
+		case p.results <- b:
+		case <-p.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// parallelInput returns an input of n statements, interspersed with blank lines and comments.
+func parallelInput(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "<http://example/s%d> <http://example/p> \"%d\"@en <http://example/g> .\n", i, i)
+		if i%100 == 0 {
+			b.WriteString("# comment\n\n")
+		}
+	}
+	return b.String()
+}
+
+func TestParallelReader(t *testing.T) {
+	input := parallelInput(2000)
+	want, err := ReadAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	for _, unordered := range []bool{false, true} {
+		for _, workers := range []int{0, 1, 3} {
+			p := NewParallelReader(strings.NewReader(input), workers)
+			p.Unordered = unordered
+			var got []Quad
+			for p.Next() {
+				got = append(got, p.Quad())
+			}
+			if p.Err() != nil {
+				t.Fatalf("unordered=%v workers=%d: got unexpected error %q", unordered, workers, p.Err())
+			}
+			if len(got) != len(want) {
+				t.Fatalf("unordered=%v workers=%d: got %d quads, wanted %d", unordered, workers, len(got), len(want))
+			}
+			if unordered {
+				sort.Slice(got, func(i, j int) bool { return got[i].String() < got[j].String() })
+				sorted := append([]Quad(nil), want...)
+				sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+				want = sorted
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("unordered=%v workers=%d: quad %d: got %s, wanted %s", unordered, workers, i, got[i], want[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestParallelReaderError(t *testing.T) {
+	input := "\uFEFF" + parallelInput(1500) + "<http://example/s> bad\n" + parallelInput(10)
+	p := NewParallelReader(strings.NewReader(input), 4)
+	n := 0
+	for p.Next() {
+		n++
+	}
+	if n != 1500 {
+		t.Errorf("got %d quads before the error, wanted 1500", n)
+	}
+	var perr *ParseError
+	if !errors.As(p.Err(), &perr) {
+		t.Fatalf("got error %v, wanted a ParseError", p.Err())
+	}
+	if perr.Line != 1500+30+1 || perr.Column != 19 {
+		t.Errorf("got error at line %d column %d, wanted line %d column 19", perr.Line, perr.Column, 1500+30+1)
+	}
+}
+
+func TestParallelReaderOptions(t *testing.T) {
+	input := parallelInput(1000) + parallelInput(1000)
+
+	p := NewParallelReader(strings.NewReader(input), 4, WithDedup(2000), WithSubjects("http://example/s1", "http://example/s2"))
+	n := 0
+	for p.Next() {
+		n++
+	}
+	if p.Err() != nil || n != 2 {
+		t.Errorf("got %d quads and error %v, wanted 2 quads", n, p.Err())
+	}
+
+	p = NewParallelReader(strings.NewReader(input), 4, WithMaxQuads(700))
+	n = 0
+	for p.Next() {
+		n++
+	}
+	if p.Err() != nil || n != 700 {
+		t.Errorf("got %d quads and error %v, wanted 700 quads", n, p.Err())
+	}
+
+	p = NewParallelReader(strings.NewReader(input+"bad\n"+input), 4, WithSkipInvalid(nil))
+	n = 0
+	for p.Next() {
+		n++
+	}
+	if p.Err() != nil || n != 4000 {
+		t.Errorf("got %d quads and error %v, wanted 4000 quads", n, p.Err())
+	}
+}
+
+func TestParallelReaderClose(t *testing.T) {
+	p := NewParallelReader(strings.NewReader(parallelInput(5000)), 2)
+	if !p.Next() {
+		t.Fatalf("got unexpected error %q", p.Err())
+	}
+	p.Close()
+	if p.Next() {
+		t.Errorf("got quad after Close")
+	}
+	if p.Err() != nil {
+		t.Errorf("got unexpected error %q", p.Err())
+	}
+}
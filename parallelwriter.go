@@ -0,0 +1,155 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultShardBytes is the size of the buffer of each shard of a ParallelWriter when ShardBytes is not
+// positive.
+const defaultShardBytes = 64 << 10
+
+// A ParallelWriter writes quads using the N-Quads encoding on behalf of many goroutines at once. Each quad
+// is serialized into one of several shards, each with its own lock and buffer, and a shard's buffer is
+// written to the underlying io.Writer once it holds ShardBytes bytes. Goroutines writing at the same time
+// use different shards, so no single lock is contended by every Write.
+//
+// Statements are never split between writes to the underlying io.Writer, but quads written by different
+// goroutines, or by one goroutine to different shards, may appear in any order. Format holds the settings
+// used to serialize each quad, as described by Writer, and can be changed before the first call to Write.
+// Its BlankNodes, FlushQuads and FlushBytes fields are ignored.
+//
+// Errors encountered while writing are reported by the next call to Write, Flush or Close.
+type ParallelWriter struct {
+	Format     Writer // the settings used to serialize each quad
+	ShardBytes int    // the number of bytes buffered by each shard before it is written, 64KB if not positive
+
+	format Writer // a copy of Format made by the first Write
+	w      io.Writer
+	wmu    sync.Mutex // guards writes to w
+	shards []writerShard
+	next   atomic.Uint32 // the shard tried first by the next Write
+	once   sync.Once     // fixes the settings on the first Write
+	closed atomic.Bool
+
+	errMu sync.Mutex // guards err
+	err   error
+}
+
+// A writerShard buffers statements serialized by a ParallelWriter.
+type writerShard struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// NewParallelWriter returns a new ParallelWriter that writes to w using the given number of shards. If
+// shards is not positive the value of runtime.GOMAXPROCS is used.
+func NewParallelWriter(w io.Writer, shards int) *ParallelWriter {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	return &ParallelWriter{
+		w:      w,
+		shards: make([]writerShard, shards),
+	}
+}
+
+// Err returns the first error encountered while writing, if any.
+func (p *ParallelWriter) Err() error {
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	return p.err
+}
+
+func (p *ParallelWriter) setErr(err error) {
+	if err == nil {
+		return
+	}
+	p.errMu.Lock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.errMu.Unlock()
+}
+
+// Write serializes q into the buffer of a shard, writing the buffer to the underlying io.Writer if it is
+// full. It returns the first error encountered while writing, in which case q is not written.
+func (p *ParallelWriter) Write(q Quad) error {
+	if p.closed.Load() {
+		return ErrWriterClosed
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	p.once.Do(func() {
+		p.format = p.Format
+		p.format.BlankNodes = nil
+		if p.ShardBytes <= 0 {
+			p.ShardBytes = defaultShardBytes
+		}
+	})
+
+	s := p.lockShard()
+	defer s.mu.Unlock()
+	var err error
+	if s.buf, err = p.format.appendStatement(s.buf, q); err != nil {
+		return err
+	}
+	if len(s.buf) >= p.ShardBytes {
+		return p.flushShard(s)
+	}
+	return nil
+}
+
+// lockShard locks and returns a shard, preferring one that is not in use by another goroutine.
+func (p *ParallelWriter) lockShard() *writerShard {
+	start := int(p.next.Add(1) % uint32(len(p.shards)))
+	for i := range p.shards {
+		s := &p.shards[(start+i)%len(p.shards)]
+		if s.mu.TryLock() {
+			return s
+		}
+	}
+	s := &p.shards[start]
+	s.mu.Lock()
+	return s
+}
+
+// flushShard writes the buffer of s, which must be locked, to the underlying io.Writer.
+func (p *ParallelWriter) flushShard(s *writerShard) error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	p.wmu.Lock()
+	_, err := p.w.Write(s.buf)
+	p.wmu.Unlock()
+	s.buf = s.buf[:0]
+	p.setErr(err)
+	return err
+}
+
+// Flush writes the buffers of all the shards to the underlying io.Writer. It returns the first error
+// encountered while writing.
+func (p *ParallelWriter) Flush() error {
+	for i := range p.shards {
+		s := &p.shards[i]
+		s.mu.Lock()
+		p.flushShard(s)
+		s.mu.Unlock()
+	}
+	return p.Err()
+}
+
+// Close flushes the ParallelWriter, after which Write returns ErrWriterClosed. It does not close the
+// underlying io.Writer. It returns the first error encountered while writing.
+func (p *ParallelWriter) Close() error {
+	p.closed.Store(true)
+	return p.Flush()
+}
@@ -0,0 +1,83 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestParallelWriter(t *testing.T) {
+	const goroutines, perGoroutine = 8, 500
+
+	var buf bytes.Buffer
+	p := NewParallelWriter(&buf, 3)
+	p.Format.UseCRLF = true
+	p.Format.BlankNodes = new(BlankNodeMap) // ignored
+	p.ShardBytes = 1024
+
+	var want []string
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		for i := 0; i < perGoroutine; i++ {
+			want = append(want, fmt.Sprintf("<http://example/s%d> <http://example/p> \"%d\" _:g%d .\r\n", i, i, g))
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				q := Quad{S: rdf.IRI(fmt.Sprintf("http://example/s%d", i)), P: rdf.IRI("http://example/p"), O: rdf.Literal(fmt.Sprint(i)), G: rdf.Blank(fmt.Sprintf("g%d", g))}
+				if err := p.Write(q); err != nil {
+					t.Errorf("got unexpected error %q", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := p.Close(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	got := strings.SplitAfter(buf.String(), "\n")
+	got = got[:len(got)-1]
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got line %q, wanted %q", got[i], want[i])
+		}
+	}
+
+	if err := p.Write(messageCases[0].quad); err != ErrWriterClosed {
+		t.Errorf("got error %v after close, wanted %v", err, ErrWriterClosed)
+	}
+}
+
+func TestParallelWriterError(t *testing.T) {
+	p := NewParallelWriter(failingWriter{}, 2)
+	if err := p.Write(messageCases[0].quad); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if err := p.Flush(); err == nil {
+		t.Errorf("flush: got no error, wanted write failure")
+	}
+	if err := p.Write(messageCases[0].quad); err == nil {
+		t.Errorf("write: got no error, wanted write failure")
+	}
+	if err := p.Close(); err == nil {
+		t.Errorf("close: got no error, wanted write failure")
+	}
+}
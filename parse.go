@@ -0,0 +1,43 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"unsafe"
+)
+
+// ReadAll reads all quads from src using a Reader configured using opts. If an error is encountered it is
+// returned together with the quads read before it.
+func ReadAll(src io.Reader, opts ...Option) ([]Quad, error) {
+	return readAll(NewReader(src, opts...))
+}
+
+// readAll reads all quads from r.
+func readAll(r *Reader) ([]Quad, error) {
+	var quads []Quad
+	for r.Next() {
+		quads = append(quads, r.Quad())
+	}
+	return quads, r.Err()
+}
+
+// ParseString parses all the statements in s. The strings of the terms returned may share memory with s.
+func ParseString(s string) ([]Quad, error) {
+	return readAll(newMemoryReader(unsafe.Slice(unsafe.StringData(s), len(s)), true))
+}
+
+// ParseBytes parses all the statements in b. The terms returned do not share memory with b; use
+// NewBytesReader to avoid copying.
+func ParseBytes(b []byte) ([]Quad, error) {
+	return readAll(newMemoryReader(b, false))
+}
+
+// ParseQuad parses a single statement held in line, which may end with a line terminator and a comment. It
+// returns ErrEmptyMessage if line holds no statement and ErrTrailingData if it holds more than one line.
+func ParseQuad(line string) (Quad, error) {
+	return DecodeMessage([]byte(line))
+}
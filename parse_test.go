@@ -0,0 +1,131 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestReadAll(t *testing.T) {
+	quads, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if len(quads) != 8 {
+		t.Errorf("got %d quads, wanted 8", len(quads))
+	}
+
+	quads, err = ReadAll(strings.NewReader("<http://example/s> <http://example/p> <http://example/o> .\n<s> <http://example/p> <http://example/o> .\n"))
+	if !errors.Is(err, ErrRelativeIRI) {
+		t.Errorf("got error %v, wanted %v", err, ErrRelativeIRI)
+	}
+	if len(quads) != 1 {
+		t.Errorf("got %d quads before the error, wanted 1", len(quads))
+	}
+
+	quads, err = ReadAll(strings.NewReader("<s> <http://example/p> <http://example/o> .\n"), WithBaseIRI("http://example/"))
+	if err != nil || len(quads) != 1 {
+		t.Errorf("got %d quads and error %v, wanted 1 quad", len(quads), err)
+	}
+}
+
+func TestParseStringAndBytes(t *testing.T) {
+	want, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	for name, parse := range map[string]func() ([]Quad, error){
+		"string": func() ([]Quad, error) { return ParseString(datasetInput) },
+		"bytes":  func() ([]Quad, error) { return ParseBytes([]byte(datasetInput)) },
+	} {
+		got, err := parse()
+		if err != nil {
+			t.Fatalf("%s: got unexpected error %q", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d quads, wanted %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: got %s, wanted %s", name, got[i], want[i])
+			}
+		}
+	}
+
+	if quads, err := ParseString(""); err != nil || len(quads) != 0 {
+		t.Errorf("got %d quads and error %v for empty input", len(quads), err)
+	}
+}
+
+func TestParseQuad(t *testing.T) {
+	for _, tc := range messageCases {
+		got, err := ParseQuad(tc.encoded + " # comment\n")
+		if err != nil {
+			t.Fatalf("%s: got unexpected error %q", tc.name, err)
+		}
+		if got != tc.quad {
+			t.Errorf("%s: got %s, wanted %s", tc.name, got, tc.quad)
+		}
+	}
+
+	testCases := []struct {
+		line string
+		err  error
+	}{
+		{line: "", err: ErrEmptyMessage},
+		{line: "# comment", err: ErrEmptyMessage},
+		{line: "_:a <http://example/p> _:b .\n_:a <http://example/p> _:c .", err: ErrTrailingData},
+		{line: "_:a <http://example/p> _:b . _:c", err: ErrUnexpectedCharacter},
+		{line: "_:a <http://example/p> _:b", err: ErrUnexpectedEOF},
+	}
+	for _, tc := range testCases {
+		if _, err := ParseQuad(tc.line); !errors.Is(err, tc.err) {
+			t.Errorf("%q: got error %v, wanted %v", tc.line, err, tc.err)
+		}
+	}
+}
+
+func TestParseTerm(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want rdf.Term
+		err  error
+	}{
+		{s: "<http://example/s>", want: rdf.IRI("http://example/s")},
+		{s: "  _:b1\t", want: rdf.Blank("b1")},
+		{s: `"chat"@fr`, want: rdf.LiteralWithLanguage("chat", "fr")},
+		{s: `"1"^^<http://www.w3.org/2001/XMLSchema#integer>`, want: rdf.LiteralWithDatatype("1", xsdInteger)},
+		{s: `"café"`, want: rdf.Literal("café")},
+		{s: "<< <http://example/s> <http://example/p> _:o >>", want: QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), rdf.Blank("o"))},
+		{s: "", err: ErrUnexpectedEOF},
+		{s: "<relative>", err: ErrRelativeIRI},
+		{s: `"1"^^<dt>`, err: ErrRelativeIRI},
+		{s: "<http://example/s> <http://example/p>", err: ErrUnexpectedCharacter},
+		{s: "plain", err: ErrUnexpectedCharacter},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseTerm(tc.s)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%q: got error %v, wanted %v", tc.s, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: got unexpected error %q", tc.s, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q: got %#v, wanted %#v", tc.s, got, tc.want)
+		}
+	}
+}
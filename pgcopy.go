@@ -0,0 +1,140 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/iand/gordf"
+)
+
+// A PostgresCopyColumn identifies the value written to a column by a PostgresCopyWriter.
+type PostgresCopyColumn int
+
+const (
+	PostgresCopySubject        PostgresCopyColumn = iota // the N-Quads serialization of the subject
+	PostgresCopyPredicate                                // the N-Quads serialization of the predicate
+	PostgresCopyObject                                   // the N-Quads serialization of the object
+	PostgresCopyGraph                                    // the N-Quads serialization of the graph, NULL for the default graph
+	PostgresCopySubjectValue                             // the IRI or blank node label of the subject
+	PostgresCopyPredicateValue                           // the IRI of the predicate
+	PostgresCopyObjectValue                              // the IRI, blank node label or lexical value of the object
+	PostgresCopyGraphValue                               // the IRI or blank node label of the graph, NULL for the default graph
+	PostgresCopyObjectLanguage                           // the language tag of the object, NULL if it has none
+	PostgresCopyObjectDatatype                           // the datatype IRI of the object, NULL if it has none
+)
+
+// A PostgresCopyWriter writes quads as rows in the text format used by the PostgreSQL COPY command, suitable
+// for bulk loading with COPY ... FROM STDIN. Columns are separated by tabs and rows are terminated by a
+// newline.
+//
+// As returned by NewPostgresCopyWriter, a PostgresCopyWriter writes the subject, predicate, object and graph
+// of each quad using their N-Quads serialization. The exported Columns field can be changed to customize the
+// columns written before the first call to Write.
+type PostgresCopyWriter struct {
+	Columns []PostgresCopyColumn // the columns written for each quad, in order
+
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewPostgresCopyWriter returns a new PostgresCopyWriter that writes to w.
+func NewPostgresCopyWriter(w io.Writer) *PostgresCopyWriter {
+	return &PostgresCopyWriter{
+		Columns: []PostgresCopyColumn{PostgresCopySubject, PostgresCopyPredicate, PostgresCopyObject, PostgresCopyGraph},
+		w:       bufio.NewWriter(w),
+	}
+}
+
+// Write writes a single quad as a row to w. Writes are buffered, so Flush must eventually be called to
+// ensure that the row is written to the underlying io.Writer.
+func (c *PostgresCopyWriter) Write(q Quad) error {
+	c.buf = c.buf[:0]
+	for i, col := range c.Columns {
+		if i > 0 {
+			c.buf = append(c.buf, '\t')
+		}
+
+		var value string
+		null := false
+		switch col {
+		case PostgresCopySubject:
+			value = termText(q.S)
+		case PostgresCopyPredicate:
+			value = termText(q.P)
+		case PostgresCopyObject:
+			value = termText(q.O)
+		case PostgresCopyGraph:
+			value, null = termText(q.G), q.G.Kind == rdf.UnknownTerm
+		case PostgresCopySubjectValue:
+			value = q.S.Value
+		case PostgresCopyPredicateValue:
+			value = q.P.Value
+		case PostgresCopyObjectValue:
+			o, err := UnescapeTerm(q.O)
+			if err != nil {
+				return err
+			}
+			value = o.Value
+		case PostgresCopyGraphValue:
+			value, null = q.G.Value, q.G.Kind == rdf.UnknownTerm
+		case PostgresCopyObjectLanguage:
+			value, null = q.O.Language, q.O.Language == ""
+		case PostgresCopyObjectDatatype:
+			value, null = q.O.Datatype, q.O.Datatype == ""
+		default:
+			null = true
+		}
+
+		if null {
+			c.buf = append(c.buf, `\N`...)
+			continue
+		}
+		c.buf = appendCopyText(c.buf, value)
+	}
+	c.buf = append(c.buf, '\n')
+
+	_, err := c.w.Write(c.buf)
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer. To check if an error occurred during the
+// Flush, call Error.
+func (c *PostgresCopyWriter) Flush() {
+	c.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (c *PostgresCopyWriter) Error() error {
+	_, err := c.w.Write(nil)
+	return err
+}
+
+// appendCopyText appends s to dst, escaping the characters that have special meaning in the COPY text format.
+func appendCopyText(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			dst = append(dst, '\\', '\\')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\v':
+			dst = append(dst, '\\', 'v')
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,92 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestPostgresCopyWriter(t *testing.T) {
+	testCases := []struct {
+		name    string
+		columns []PostgresCopyColumn
+		quad    Quad
+		want    string
+	}{
+		{
+			name: "default-columns",
+			quad: Quad{
+				S: rdf.IRI("http://example/s"),
+				P: rdf.IRI("http://example/p"),
+				O: rdf.LiteralWithLanguage("chat", "fr"),
+				G: rdf.IRI("http://example/g"),
+			},
+			want: "<http://example/s>\t<http://example/p>\t\"chat\"@fr\t<http://example/g>\n",
+		},
+		{
+			name: "default-graph",
+			quad: Quad{
+				S: rdf.Blank("b1"),
+				P: rdf.IRI("http://example/p"),
+				O: rdf.IRI("http://example/o"),
+			},
+			want: "_:b1\t<http://example/p>\t<http://example/o>\t\\N\n",
+		},
+		{
+			name: "escaping",
+			quad: Quad{
+				S: rdf.IRI("http://example/s"),
+				P: rdf.IRI("http://example/p"),
+				O: rdf.Literal("a\\b\tc\nd"),
+			},
+			want: "<http://example/s>\t<http://example/p>\t\"a\\\\\\\\b\\tc\\\\nd\"\t\\N\n",
+		},
+		{
+			name:    "value-columns",
+			columns: []PostgresCopyColumn{PostgresCopySubjectValue, PostgresCopyPredicateValue, PostgresCopyObjectValue, PostgresCopyObjectLanguage, PostgresCopyObjectDatatype, PostgresCopyGraphValue},
+			quad: Quad{
+				S: rdf.IRI("http://example/s"),
+				P: rdf.IRI("http://example/p"),
+				O: rdf.LiteralWithDatatype("a\tb", "http://www.w3.org/2001/XMLSchema#string"),
+			},
+			want: "http://example/s\thttp://example/p\ta\\tb\t\\N\thttp://www.w3.org/2001/XMLSchema#string\t\\N\n",
+		},
+		{
+			name:    "escaped-literal",
+			columns: []PostgresCopyColumn{PostgresCopyObject, PostgresCopyObjectValue},
+			quad: Quad{
+				S: rdf.IRI("http://example/s"),
+				P: rdf.IRI("http://example/p"),
+				O: rdf.Term{Value: `caf\u00E9\t`, Kind: EscapedLiteralTerm},
+			},
+			want: "\"caf\\\\u00E9\\\\t\"\tcafé\\t\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cw := NewPostgresCopyWriter(&buf)
+			if tc.columns != nil {
+				cw.Columns = tc.columns
+			}
+			if err := cw.Write(tc.quad); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"sync"
+)
+
+// readerPool holds Readers returned by PutReader for reuse by GetReader.
+var readerPool = sync.Pool{
+	New: func() any {
+		r := NewReader(nil)
+		r.pooled = true
+		return r
+	},
+}
+
+// GetReader returns a Reader with no options that reads from r, reusing one returned to the pool by PutReader
+// if possible. This avoids allocating a Reader and its buffers for each input when many small inputs are
+// read, such as the bodies of requests received by an HTTP server.
+func GetReader(r io.Reader) *Reader {
+	nr := readerPool.Get().(*Reader)
+	nr.Reset(r)
+	return nr
+}
+
+// PutReader returns a Reader obtained from GetReader to the pool. The Reader must not be used after it has
+// been returned, although quads already read from it remain valid. Readers not obtained from GetReader are
+// ignored.
+func PutReader(r *Reader) {
+	if r == nil || !r.pooled {
+		return
+	}
+	r.Reset(nil)
+	readerPool.Put(r)
+}
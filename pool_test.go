@@ -0,0 +1,50 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReaderPool(t *testing.T) {
+	want, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		r := GetReader(strings.NewReader(datasetInput))
+		var got []Quad
+		for r.Next() {
+			got = append(got, r.Quad())
+		}
+		if r.Err() != nil {
+			t.Fatalf("got unexpected error %q", r.Err())
+		}
+		PutReader(r)
+		if len(got) != len(want) {
+			t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+		}
+		for j := range got {
+			if got[j] != want[j] {
+				t.Errorf("got %s, wanted %s", got[j], want[j])
+			}
+		}
+	}
+
+	// a Reader configured with options must not be handed out by GetReader
+	PutReader(NewReader(strings.NewReader(""), WithMaxQuads(1)))
+	r := GetReader(strings.NewReader(datasetInput))
+	n := 0
+	for r.Next() {
+		n++
+	}
+	PutReader(r)
+	if n != len(want) {
+		t.Errorf("got %d quads from pooled reader, wanted %d", n, len(want))
+	}
+}
@@ -0,0 +1,20 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// position is a location in the input of a Reader.
+type position struct {
+	line   int
+	column int
+	offset int64
+}
+
+// Position returns the location in the input of the start of the last quad read. The line is counted from 1
+// and the column, in runes, from 0, as in a ParseError. The byte offset is counted from the start of the
+// input, before any conversion from another character set.
+func (r *Reader) Position() (line, column, byteOffset int64) {
+	return int64(r.start.line), int64(r.start.column), r.start.offset
+}
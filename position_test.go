@@ -0,0 +1,51 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPosition(t *testing.T) {
+	input := "<http://example/s> <http://example/p> \"1\" .\n" +
+		"\n" +
+		"# comment\n" +
+		"  <http://example/s> <http://example/p> \"2\" . # trailing comment\r\n" +
+		"<http://example/s> <http://example/p> \"é\" .\n" +
+		"\t<http://example/s> <http://example/p> \"3\" .\n"
+
+	type pos struct {
+		line, column, offset int64
+	}
+	want := []pos{
+		{1, 0, 0},
+		{4, 2, 57},
+		{5, 0, 121},
+		{6, 1, 167},
+	}
+
+	r := NewReader(strings.NewReader(input))
+	var got []pos
+	for r.Next() {
+		line, column, offset := r.Position()
+		got = append(got, pos{line, column, offset})
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d positions, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("quad %d: got position %v, wanted %v", i, got[i], want[i])
+		}
+		if !strings.HasPrefix(input[want[i].offset:], "<http://example/s>") {
+			t.Errorf("quad %d: offset %d does not point to the start of the statement", i, want[i].offset)
+		}
+	}
+}
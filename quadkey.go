@@ -0,0 +1,51 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"encoding/binary"
+
+	"github.com/iand/gordf"
+)
+
+// FNV-1a parameters used by Quad.Hash.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Key returns a compact binary encoding of q for use as a map key. Two quads have the same key if and only
+// if they are equal when compared with ==, including quads whose terms would serialize identically as
+// N-Quads but differ in their fields, such as a literal with no datatype and one with xsd:string. The key is
+// not intended to be read by people; use String for display.
+func (q Quad) Key() string {
+	return string(appendQuadKey(nil, q))
+}
+
+// Hash returns a 64-bit FNV-1a hash of the key of q, as returned by Key. Equal quads have the same hash. The
+// hash does not depend on the process computing it, so it may be stored or exchanged between systems.
+func (q Quad) Hash() uint64 {
+	var buf [256]byte
+	h := uint64(fnvOffset64)
+	for _, c := range appendQuadKey(buf[:0], q) {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// appendQuadKey appends the key of q to dst. Each term is encoded as its kind followed by its value,
+// language and datatype, each preceded by its length, so that no two distinct quads share an encoding.
+func appendQuadKey(dst []byte, q Quad) []byte {
+	for _, t := range [...]rdf.Term{q.S, q.P, q.O, q.G} {
+		dst = binary.AppendUvarint(dst, uint64(t.Kind))
+		for _, s := range [...]string{t.Value, t.Language, t.Datatype} {
+			dst = binary.AppendUvarint(dst, uint64(len(s)))
+			dst = append(dst, s...)
+		}
+	}
+	return dst
+}
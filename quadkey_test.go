@@ -0,0 +1,93 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"hash/fnv"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestQuadKey(t *testing.T) {
+	s := rdf.IRI("http://example/s")
+	p := rdf.IRI("http://example/p")
+
+	testCases := []struct {
+		name string
+		a, b Quad
+	}{
+		{
+			name: "object kind",
+			a:    Quad{S: s, P: p, O: rdf.IRI("x")},
+			b:    Quad{S: s, P: p, O: rdf.Literal("x")},
+		},
+		{
+			name: "plain and xsd:string literal",
+			a:    Quad{S: s, P: p, O: rdf.Literal("x")},
+			b:    Quad{S: s, P: p, O: rdf.LiteralWithDatatype("x", xsdString)},
+		},
+		{
+			name: "value and language boundary",
+			a:    Quad{S: s, P: p, O: rdf.LiteralWithLanguage("ab", "c")},
+			b:    Quad{S: s, P: p, O: rdf.LiteralWithLanguage("a", "bc")},
+		},
+		{
+			name: "term boundary",
+			a:    Quad{S: rdf.IRI("ab"), P: rdf.IRI("c")},
+			b:    Quad{S: rdf.IRI("a"), P: rdf.IRI("bc")},
+		},
+		{
+			name: "graph",
+			a:    Quad{S: s, P: p, O: s},
+			b:    Quad{S: s, P: p, O: s, G: rdf.IRI("http://example/g")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.a.Key() == tc.b.Key() {
+				t.Errorf("got the same key for %s and %s", tc.a, tc.b)
+			}
+			if tc.a.Hash() == tc.b.Hash() {
+				t.Errorf("got the same hash for %s and %s", tc.a, tc.b)
+			}
+		})
+	}
+
+	quads, err := ReadAll(strings.NewReader(datasetInput))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	keys := make(map[string]Quad)
+	for _, q := range quads {
+		if other, ok := keys[q.Key()]; ok && other != q {
+			t.Errorf("got the same key for %s and %s", q, other)
+		}
+		keys[q.Key()] = q
+	}
+	if len(keys) != 7 {
+		t.Errorf("got %d distinct keys, wanted 7", len(keys))
+	}
+}
+
+func TestQuadHash(t *testing.T) {
+	q := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal(strings.Repeat("long ", 100))}
+	copied := Quad{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.Literal(strings.Repeat("long ", 100))}
+	if q.Hash() != copied.Hash() {
+		t.Errorf("got different hashes for equal quads")
+	}
+
+	// the hash is FNV-1a of the key, so it is stable between processes
+	for _, q := range []Quad{{}, q} {
+		h := fnv.New64a()
+		h.Write([]byte(q.Key()))
+		if got, want := q.Hash(), h.Sum64(); got != want {
+			t.Errorf("got hash %#x for %s, wanted %#x", got, q, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"io"
+
+	"github.com/iand/gordf"
+)
+
+// ErrQuotedTripleDepth is the error returned in ParseError.Err when quoted triples are nested more deeply
+// than maxQuotedTripleDepth.
+var ErrQuotedTripleDepth = errors.New("quoted triples nested too deeply")
+
+// maxQuotedTripleDepth limits the nesting of quoted triples read by a Reader, which would otherwise be
+// limited only by the length of the statement.
+const maxQuotedTripleDepth = 64
+
+// QuotedTripleTerm is the kind of term that represents an RDF-star quoted triple, written as << s p o >> in
+// the subject or object of a statement. It extends the kinds of term defined by the rdf package. The Reader
+// returns quoted triples read from its input as terms of this kind.
+//
+// The Value of a quoted triple term holds the N-Quads serialization of the subject, predicate and object
+// of the quoted triple, separated by single spaces. Quoted triple terms should be created using QuotedTriple
+// so that the same triple always has the same Value and terms can be compared for equality.
+const QuotedTripleTerm = rdf.LiteralTerm + 1
+
+// QuotedTriple returns a term representing the quoted triple formed by s, p and o. The subject may itself
+// be a quoted triple, as may the object.
+func QuotedTriple(s, p, o rdf.Term) rdf.Term {
+	return rdf.Term{
+		Value: string(appendTerms(nil, Quad{S: s, P: p, O: o})),
+		Kind:  QuotedTripleTerm,
+	}
+}
+
+// parseIRIOrQuotedTriple parses an IRI or, if the '<' already read is followed by another, a quoted triple.
+func (r *Reader) parseIRIOrQuotedTriple() (rdf.Term, error) {
+	r1, err := r.readRune()
+	if err != nil {
+		if err == io.EOF {
+			return rdf.Term{}, r.wrap(ErrUnexpectedEOF)
+		}
+		return rdf.Term{}, err
+	}
+	if r1 == '<' && !r.rdf11 {
+		return r.parseQuotedTriple()
+	}
+	if err := r.unreadRune(); err != nil {
+		return rdf.Term{}, err
+	}
+	return r.parseIRI()
+}
+
+// parseQuotedTriple parses the remainder of a quoted triple after the opening "<<".
+func (r *Reader) parseQuotedTriple() (rdf.Term, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > maxQuotedTripleDepth {
+		return rdf.Term{}, r.wrap(ErrQuotedTripleDepth)
+	}
+
+	subject, err := r.parseIriOrBlankNode(true)
+	if err != nil {
+		return rdf.Term{}, err
+	}
+	if subject, err = r.checkTerm(subject); err != nil {
+		return rdf.Term{}, err
+	}
+
+	predicate, err := r.parseIriOrBlankNode(false)
+	if err != nil {
+		return rdf.Term{}, err
+	}
+	if predicate, err = r.checkTerm(predicate); err != nil {
+		return rdf.Term{}, err
+	}
+
+	object, err := r.parseAnyTerm()
+	if err != nil {
+		return rdf.Term{}, err
+	}
+	if object, err = r.checkTerm(object); err != nil {
+		return rdf.Term{}, err
+	}
+
+	r1, err := r.skipWhitespace()
+	if err == nil && r1 == '>' {
+		r1, err = r.readRune()
+	}
+	if err != nil {
+		if err == io.EOF {
+			return rdf.Term{}, r.wrap(ErrUnexpectedEOF)
+		}
+		return rdf.Term{}, err
+	}
+	if r1 != '>' {
+		return rdf.Term{}, r.wrap(ErrUnexpectedCharacter)
+	}
+	if r.discard {
+		return rdf.Term{Kind: QuotedTripleTerm}, nil
+	}
+	return QuotedTriple(subject, predicate, object), nil
+}
@@ -0,0 +1,208 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestWriterQuotedTriple(t *testing.T) {
+	inner := QuotedTriple(rdf.IRI("http://example/alice"), rdf.IRI("http://example/knows"), rdf.Blank("bob"))
+
+	testCases := []struct {
+		name string
+		quad Quad
+		want string
+	}{
+		{
+			name: "subject",
+			quad: Quad{S: inner, P: rdf.IRI("http://example/certainty"), O: rdf.LiteralWithDatatype("0.9", xsdNS+"decimal")},
+			want: `<< <http://example/alice> <http://example/knows> _:bob >> <http://example/certainty> "0.9"^^<http://www.w3.org/2001/XMLSchema#decimal> .` + "\n",
+		},
+		{
+			name: "object",
+			quad: Quad{S: rdf.IRI("http://example/carol"), P: rdf.IRI("http://example/says"), O: inner, G: rdf.IRI("http://example/g")},
+			want: `<http://example/carol> <http://example/says> << <http://example/alice> <http://example/knows> _:bob >> <http://example/g> .` + "\n",
+		},
+		{
+			name: "nested",
+			quad: Quad{S: QuotedTriple(inner, rdf.IRI("http://example/source"), rdf.IRI("http://example/doc")), P: rdf.IRI("http://example/p"), O: rdf.Literal("o")},
+			want: `<< << <http://example/alice> <http://example/knows> _:bob >> <http://example/source> <http://example/doc> >> <http://example/p> "o" .` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			if err := w.Write(tc.quad); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %s, wanted %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompareQuotedTriple(t *testing.T) {
+	quoted := QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), rdf.IRI("http://example/o"))
+	if CompareTerms(rdf.Literal("z"), quoted) >= 0 {
+		t.Errorf("literal did not sort before quoted triple")
+	}
+	if CompareTerms(quoted, rdf.IRI("http://example/a")) >= 0 {
+		t.Errorf("quoted triple did not sort before IRI")
+	}
+}
+
+func TestReadQuotedTriple(t *testing.T) {
+	inner := QuotedTriple(rdf.IRI("http://example/alice"), rdf.IRI("http://example/knows"), rdf.Blank("bob"))
+
+	testCases := []struct {
+		name  string
+		input string
+		want  Quad
+		err   error
+	}{
+		{
+			name:  "subject",
+			input: `<< <http://example/alice> <http://example/knows> _:bob >> <http://example/certainty> "0.9" .`,
+			want:  Quad{S: inner, P: rdf.IRI("http://example/certainty"), O: rdf.Literal("0.9")},
+		},
+		{
+			name:  "object",
+			input: `<http://example/carol> <http://example/says> << <http://example/alice> <http://example/knows> _:bob >> <http://example/g> .`,
+			want:  Quad{S: rdf.IRI("http://example/carol"), P: rdf.IRI("http://example/says"), O: inner, G: rdf.IRI("http://example/g")},
+		},
+		{
+			name:  "compact",
+			input: `<<<http://example/alice><http://example/knows>_:bob>><http://example/p><<<http://example/s><http://example/p>"x"@en>>.`,
+			want: Quad{
+				S: inner,
+				P: rdf.IRI("http://example/p"),
+				O: QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), rdf.LiteralWithLanguage("x", "en")),
+			},
+		},
+		{
+			name:  "nested",
+			input: `<< << <http://example/alice> <http://example/knows> _:bob >> <http://example/source> <http://example/doc> >> <http://example/p> "o" .`,
+			want:  Quad{S: QuotedTriple(inner, rdf.IRI("http://example/source"), rdf.IRI("http://example/doc")), P: rdf.IRI("http://example/p"), O: rdf.Literal("o")},
+		},
+		{
+			name:  "literal object",
+			input: `<< <http://example/s> <http://example/p> "x">> <http://example/p> "1"^^<http://example/dt> .`,
+			want:  Quad{S: QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), rdf.Literal("x")), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithDatatype("1", "http://example/dt")},
+		},
+		{
+			name:  "blank node with period",
+			input: `<< <http://example/s> <http://example/p> _:x.y>> <http://example/p> "1" .`,
+			want:  Quad{S: QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), rdf.Blank("x.y")), P: rdf.IRI("http://example/p"), O: rdf.Literal("1")},
+		},
+		{
+			name:  "period before end",
+			input: `<< <http://example/s> <http://example/p> _:x.>> <http://example/p> "1" .`,
+			err:   ErrUnexpectedCharacter,
+		},
+		{
+			name:  "predicate",
+			input: `<http://example/s> << <http://example/s> <http://example/p> <http://example/o> >> <http://example/o> .`,
+			err:   ErrUnexpectedCharacter,
+		},
+		{
+			name:  "graph",
+			input: `<http://example/s> <http://example/p> <http://example/o> << <http://example/s> <http://example/p> <http://example/o> >> .`,
+			err:   ErrUnexpectedCharacter,
+		},
+		{
+			name:  "unclosed",
+			input: `<< <http://example/s> <http://example/p> <http://example/o> > <http://example/p> <http://example/o> .`,
+			err:   ErrUnexpectedCharacter,
+		},
+		{
+			name:  "relative",
+			input: `<< <s> <http://example/p> <http://example/o> >> <http://example/p> <http://example/o> .`,
+			err:   ErrRelativeIRI,
+		},
+		{
+			name:  "too deep",
+			input: strings.Repeat("<< ", maxQuotedTripleDepth+1) + "<http://example/s>",
+			err:   ErrQuotedTripleDepth,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ParseQuad(tc.input)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("got error %v, wanted %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if q != tc.want {
+				t.Errorf("got %v, wanted %v", q, tc.want)
+			}
+		})
+	}
+}
+
+func TestQuotedTripleRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  string // the output written, the input if empty
+	}{
+		{
+			name:  "nested",
+			input: `<< << <http://example/a> <http://example/b> _:c >> <http://example/d> "e\"f"@en-GB >> <http://example/g> << <http://example/h> <http://example/i> "1"^^<http://example/dt> >> <http://example/j> .` + "\n",
+		},
+		{
+			name:  "blank node with period",
+			input: `<< _:x.y <http://example/p> _:z.w>> <http://example/p> "1" .` + "\n",
+			want:  `<< _:x.y <http://example/p> _:z.w >> <http://example/p> "1" .` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quads, err := ParseString(tc.input)
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			if err := w.WriteAll(quads); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			want := tc.want
+			if want == "" {
+				want = tc.input
+			}
+			if buf.String() != want {
+				t.Errorf("got %s, wanted %s", buf.String(), want)
+			}
+
+			again, err := ParseString(buf.String())
+			if err != nil {
+				t.Fatalf("got unexpected error reading output %q", err)
+			}
+			if !slices.Equal(again, quads) {
+				t.Errorf("got %v after round trip, wanted %v", again, quads)
+			}
+		})
+	}
+}
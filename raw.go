@@ -0,0 +1,111 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// A RawQuad is a quad together with the source bytes of the line it was read from.
+type RawQuad struct {
+	Quad
+	Raw []byte // the line holding the statement, including any trailing comment and line terminator
+}
+
+// WithRawCapture configures the Reader to record the source bytes of the line holding each statement,
+// which can be obtained using RawQuad. The bytes include any leading whitespace, trailing comment and line
+// terminator but exclude preceding blank and comment lines.
+func WithRawCapture() Option {
+	return func(r *Reader) {
+		r.capture = true
+	}
+}
+
+// RawQuad returns the last quad read together with its source bytes. Raw is nil unless the Reader was
+// configured using WithRawCapture. Raw is only valid until the next call to Next.
+func (r *Reader) RawQuad() RawQuad {
+	rq := RawQuad{Quad: *r.q}
+	if r.capture {
+		rq.Raw = r.raw
+	}
+	return rq
+}
+
+// WriteRaw writes raw to w without modification, followed by a line terminator if raw does not already end
+// with a newline. It is intended for copying statements that have already been validated, such as the Raw
+// field of a RawQuad.
+func (w *Writer) WriteRaw(raw []byte) error {
+	if _, err := w.w.Write(raw); err != nil {
+		return err
+	}
+	if len(raw) > 0 && raw[len(raw)-1] == '\n' {
+		return nil
+	}
+	if w.UseCRLF {
+		if err := w.w.WriteByte('\r'); err != nil {
+			return err
+		}
+	}
+	return w.w.WriteByte('\n')
+}
+
+// WriteRawQuad writes the source bytes of rq to w using WriteRaw, or serializes rq.Quad if it has no
+// source bytes.
+func (w *Writer) WriteRawQuad(rq RawQuad) error {
+	if rq.Raw == nil {
+		return w.Write(rq.Quad)
+	}
+	return w.WriteRaw(rq.Raw)
+}
+
+// PassThrough reads all quads from src and copies those for which keep returns true to dst, byte for byte as
+// they appeared in the input, so that filtering never alters the lines that are kept. Blank lines and comment
+// lines are not copied. Raw capture is enabled on src if it was not already. PassThrough returns the number
+// of statements copied. It does not flush dst.
+func PassThrough(dst *Writer, src *Reader, keep func(Quad) bool) (int64, error) {
+	src.capture = true
+
+	var n int64
+	for src.Next() {
+		rq := src.RawQuad()
+		if !keep(rq.Quad) {
+			continue
+		}
+		if err := dst.WriteRaw(rq.Raw); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, src.Err()
+}
+
+// Copy reads all quads from src and writes them to dst, returning the number of statements copied. It does
+// not flush dst.
+//
+// If dst and src are configured to write and read quads without transformation, each statement is copied
+// byte for byte as it appeared in the input, as by PassThrough, avoiding the cost of encoding it again. The
+// statements are still fully parsed, so Copy validates its input. Otherwise, including when src rewrites the
+// quads it reads, for example with WithBaseIRI or WithBlankNodeMapper, each quad is written using dst.Write.
+func Copy(dst *Writer, src *Reader) (int64, error) {
+	if dst.transforms() || src.transforms() {
+		var n int64
+		for src.Next() {
+			if err := dst.Write(src.Quad()); err != nil {
+				return n, err
+			}
+			n++
+		}
+		return n, src.Err()
+	}
+	return PassThrough(dst, src, func(Quad) bool { return true })
+}
+
+// transforms reports whether r is configured to return quads that differ from the statements it reads, so
+// that the raw bytes of a statement cannot stand in for its quad.
+func (r *Reader) transforms() bool {
+	return r.base != "" || r.mapBlank != nil || r.escapedLiterals || r.stringDatatype || r.truncated
+}
+
+// transforms reports whether w is configured to write quads in any form other than the default.
+func (w *Writer) transforms() bool {
+	return w.UseCRLF || w.OmitSpaceBeforeDot || w.EscapeASCII || w.BlankNodes != nil || w.NormalizeLanguage || w.OmitStringDatatype
+}
@@ -0,0 +1,202 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRawQuad(t *testing.T) {
+	lines := []string{
+		"<http://example/s> <http://example/p> \"caf\\u00E9\" .\n",
+		"  _:b1\t<http://example/p>   <http://example/o> <http://example/g>.  # comment\r\n",
+		"<http://example/s> <http://example/p> \"a\\tb\"@en .",
+	}
+	input := "# header\n\n" + lines[0] + "# between\n" + lines[1] + lines[2]
+
+	r := NewReader(strings.NewReader(input), WithRawCapture())
+	for i, want := range lines {
+		if !r.Next() {
+			t.Fatalf("quad %d: missing (err=%v)", i, r.Err())
+		}
+		if got := string(r.RawQuad().Raw); got != want {
+			t.Errorf("quad %d: got raw %q, wanted %q", i, got, want)
+		}
+	}
+	if r.Next() {
+		t.Errorf("got additional unexpected quad %s", r.Quad())
+	}
+	if r.Err() != nil {
+		t.Errorf("got unexpected error %q", r.Err())
+	}
+}
+
+func TestRawQuadInvalidUTF8(t *testing.T) {
+	line := "<http://example/s> <http://example/p> \"\xff\xfe\" .\n"
+	r := NewReader(strings.NewReader(line), WithRawCapture())
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if got := string(r.RawQuad().Raw); got != line {
+		t.Errorf("got raw %q, wanted %q", got, line)
+	}
+}
+
+func TestRawQuadNotCaptured(t *testing.T) {
+	r := NewReader(strings.NewReader("<http://example/s> <http://example/p> <http://example/o> .\n"))
+	if !r.Next() {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if raw := r.RawQuad().Raw; raw != nil {
+		t.Errorf("got raw %q, wanted nil", raw)
+	}
+}
+
+func TestPassThrough(t *testing.T) {
+	input := `# header
+<http://example/s>   <http://example/keep> "é" .
+<http://example/s> <http://example/drop> "x" .
+<http://example/s> <http://example/keep> "\t"@EN-gb . # note
+<http://example/s> <http://example/keep> _:b1.`
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	n, err := PassThrough(w, NewReader(strings.NewReader(input)), func(q Quad) bool {
+		return q.P.Value == "http://example/keep"
+	})
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	w.Flush()
+
+	if n != 3 {
+		t.Errorf("got %d quads copied, wanted 3", n)
+	}
+	want := `<http://example/s>   <http://example/keep> "é" .
+<http://example/s> <http://example/keep> "\t"@EN-gb . # note
+<http://example/s> <http://example/keep> _:b1.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	input := "# header\n<http://example/s> <http://example/p> \"caf\\u00E9\" .\n_:b1  <http://example/p> <http://example/o> <http://example/g>. # comment\n"
+
+	testCases := []struct {
+		name   string
+		config func(*Writer)
+		want   string
+	}{
+		{
+			name: "raw",
+			want: "<http://example/s> <http://example/p> \"caf\\u00E9\" .\n_:b1  <http://example/p> <http://example/o> <http://example/g>. # comment\n",
+		},
+		{
+			name:   "transformed",
+			config: func(w *Writer) { w.BlankNodes = &BlankNodeMap{} },
+			want:   "<http://example/s> <http://example/p> \"café\" .\n_:b0 <http://example/p> <http://example/o> <http://example/g> .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			if tc.config != nil {
+				tc.config(w)
+			}
+			n, err := Copy(w, NewReader(strings.NewReader(input)))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if n != 2 {
+				t.Errorf("got %d statements copied, wanted 2", n)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCopyReaderTransforms(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		opt   Option
+		want  string
+	}{
+		{
+			name:  "base iri",
+			input: "<s> <http://example/p> <o> .\n",
+			opt:   WithBaseIRI("http://example/"),
+			want:  "<http://example/s> <http://example/p> <http://example/o> .\n",
+		},
+		{
+			name:  "blank node mapper",
+			input: "_:b1 <http://example/p> _:b2 .\n",
+			opt:   WithBlankNodeMapper(func(label string) string { return "x" + label }),
+			want:  "_:xb1 <http://example/p> _:xb2 .\n",
+		},
+		{
+			name:  "escaped literals",
+			input: "<http://example/s>  <http://example/p> \"a\\tb\" .\n",
+			opt:   WithEscapedLiterals(),
+			want:  "<http://example/s> <http://example/p> \"a\\tb\" .\n",
+		},
+		{
+			name:  "string datatype",
+			input: "<http://example/s> <http://example/p> \"a\" .\n",
+			opt:   WithStringDatatype(),
+			want:  "<http://example/s> <http://example/p> \"a\"^^<http://www.w3.org/2001/XMLSchema#string> .\n",
+		},
+		{
+			name:  "truncated input",
+			input: "<http://example/s> <http://example/p> <http://example/o>",
+			opt:   WithTruncatedInput(),
+			want:  "<http://example/s> <http://example/p> <http://example/o> .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			n, err := Copy(w, NewReader(strings.NewReader(tc.input), tc.opt))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if n != 1 {
+				t.Errorf("got %d statements copied, wanted 1", n)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCopyInvalid(t *testing.T) {
+	input := "<http://example/s> <http://example/p> <http://example/o> .\n<http://example/s> <http://example/p> .\n"
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	n, err := Copy(w, NewReader(strings.NewReader(input)))
+	if err == nil {
+		t.Fatalf("got no error, wanted one")
+	}
+	if n != 1 {
+		t.Errorf("got %d statements copied, wanted 1", n)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+	"runtime"
+)
+
+// The defaults used by WithReadAhead for arguments that are not positive.
+const (
+	defaultReadAheadBuffers = 4
+	defaultReadAheadSize    = 256 << 10
+)
+
+// WithReadAhead configures the Reader to read from its underlying reader on a background goroutine, which
+// keeps up to n buffers of size bytes filled ahead of the parser. Work done by the underlying reader, such as
+// decompression by a gzip.Reader or waiting for a network connection, then overlaps with parsing rather than
+// alternating with it. Values of n or size that are not positive select four buffers of 256KB.
+//
+// The goroutine stops at the end of the input or after the underlying reader returns an error. If the Reader
+// is discarded before then, the goroutine stops once the Reader is garbage collected, but may have read
+// further from the underlying reader than the Reader consumed.
+func WithReadAhead(n, size int) Option {
+	return func(r *Reader) {
+		if n <= 0 {
+			n = defaultReadAheadBuffers
+		}
+		if size <= 0 {
+			size = defaultReadAheadSize
+		}
+		r.readAhead = n
+		r.readAheadSize = size
+	}
+}
+
+// A readAhead is an io.Reader that returns the data read by a readAheadFiller.
+type readAhead struct {
+	*readAheadFiller
+	buf []byte // the buffer holding cur, to be returned to the filler once consumed
+	cur []byte // the data yet to be returned by Read
+	err error  // the error to be returned once cur is consumed
+}
+
+// A readAheadFiller reads from an io.Reader into a ring of buffers on a background goroutine.
+type readAheadFiller struct {
+	full chan readAheadChunk // buffers filled by the goroutine, in order
+	free chan []byte         // buffers available to be filled
+	stop chan struct{}       // closed to stop the goroutine
+}
+
+type readAheadChunk struct {
+	b   []byte
+	err error
+}
+
+// newReadAhead returns a readAhead that reads from r using n buffers of the given size.
+func newReadAhead(r io.Reader, n, size int) *readAhead {
+	f := &readAheadFiller{
+		full: make(chan readAheadChunk, n),
+		free: make(chan []byte, n),
+		stop: make(chan struct{}),
+	}
+	for range n {
+		f.free <- make([]byte, size)
+	}
+	go f.fill(r)
+
+	// the goroutine only refers to the filler, so the readAhead can be collected once it is discarded
+	ra := &readAhead{readAheadFiller: f}
+	runtime.SetFinalizer(ra, func(ra *readAhead) { close(ra.stop) })
+	return ra
+}
+
+func (f *readAheadFiller) fill(r io.Reader) {
+	for {
+		var b []byte
+		select {
+		case b = <-f.free:
+		case <-f.stop:
+			return
+		}
+		n, err := r.Read(b)
+		select {
+		case f.full <- readAheadChunk{b: b[:n], err: err}:
+		case <-f.stop:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (ra *readAhead) Read(p []byte) (int, error) {
+	for len(ra.cur) == 0 {
+		if ra.err != nil {
+			return 0, ra.err
+		}
+		if ra.buf != nil {
+			ra.free <- ra.buf[:cap(ra.buf)]
+		}
+		c := <-ra.full
+		ra.buf, ra.cur, ra.err = c.b, c.b, c.err
+	}
+	n := copy(p, ra.cur)
+	ra.cur = ra.cur[n:]
+	return n, nil
+}
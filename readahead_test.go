@@ -0,0 +1,81 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadAhead(t *testing.T) {
+	input := parallelInput(500)
+	want, err := ReadAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	io.WriteString(zw, input)
+	zw.Close()
+
+	testCases := []struct {
+		name string
+		src  func() io.Reader
+		n    int
+		size int
+	}{
+		{name: "defaults", src: func() io.Reader { return strings.NewReader(input) }},
+		{name: "small buffers", src: func() io.Reader { return strings.NewReader(input) }, n: 1, size: 16},
+		{name: "one byte reads", src: func() io.Reader { return iotest.OneByteReader(strings.NewReader(input)) }, n: 2, size: 64},
+		{
+			name: "gzip",
+			src: func() io.Reader {
+				zr, err := gzip.NewReader(bytes.NewReader(compressed.Bytes()))
+				if err != nil {
+					t.Fatalf("got unexpected error %q", err)
+				}
+				return zr
+			},
+			n:    3,
+			size: 1024,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadAll(tc.src(), WithReadAhead(tc.n, tc.size))
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d quads, wanted %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("got %s, wanted %s", got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadAheadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	src := io.MultiReader(strings.NewReader(parallelInput(100)), iotest.ErrReader(errRead))
+	got, err := ReadAll(src, WithReadAhead(2, 512))
+	if !errors.Is(err, errRead) {
+		t.Errorf("got error %v, wanted %v", err, errRead)
+	}
+	if len(got) != 100 {
+		t.Errorf("got %d quads before the error, wanted 100", len(got))
+	}
+}
@@ -0,0 +1,54 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"sync"
+)
+
+// A SafeWriter wraps a Writer so that it may be used by multiple goroutines simultaneously. Each statement is
+// written in its entirety before another is started, so statements from different goroutines are never
+// interleaved, although their relative order is not defined.
+type SafeWriter struct {
+	mu sync.Mutex
+	w  *Writer
+}
+
+// NewSafeWriter returns a new SafeWriter that writes to w. The Writer should not be used directly while the
+// SafeWriter is in use.
+func NewSafeWriter(w *Writer) *SafeWriter {
+	return &SafeWriter{w: w}
+}
+
+// Write writes a single quad to the underlying Writer.
+func (s *SafeWriter) Write(q Quad) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(q)
+}
+
+// WriteAll writes multiple quads to the underlying Writer and then flushes it. No statements from other
+// goroutines are written between them.
+func (s *SafeWriter) WriteAll(quads []Quad) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.WriteAll(quads)
+}
+
+// Flush writes any buffered data to the underlying io.Writer. To check if an error occurred during the
+// Flush, call Error.
+func (s *SafeWriter) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (s *SafeWriter) Error() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Error()
+}
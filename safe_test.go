@@ -0,0 +1,59 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestSafeWriter(t *testing.T) {
+	const workers, perWorker = 8, 500
+
+	var buf bytes.Buffer
+	w := NewSafeWriter(NewWriterSize(&buf, 64))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				q := Quad{
+					S: rdf.IRI("http://example/s" + strconv.Itoa(worker)),
+					P: rdf.IRI("http://example/p"),
+					O: rdf.Literal(strconv.Itoa(j)),
+				}
+				if err := w.Write(q); err != nil {
+					t.Errorf("got unexpected error %q", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	w.Flush()
+	if err := w.Error(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	// Every statement must be intact
+	d := NewDataset()
+	r := NewReader(&buf)
+	for r.Next() {
+		d.Add(r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if d.Len() != workers*perWorker {
+		t.Errorf("got %d quads, wanted %d", d.Len(), workers*perWorker)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// A lineScanner splits an input into lines, each of which holds at most one N-Quads statement. A line ends
+// at \n, \r\n or a lone \r.
+type lineScanner struct {
+	r      *bufio.Reader
+	data   []byte // the input, if r is nil
+	max    int    // the number of bytes of a line returned, unlimited if zero
+	long   []byte // holds a line that does not fit in the buffer of r
+	rest   bool   // whether the remainder of the last line scanned is still to be discarded
+	n      int    // the number of lines scanned
+	offset int64  // the offset of the start of the last line scanned
+	next   int64  // the offset of the next byte to be scanned
+}
+
+// scan returns the next line of the input, including its line terminator. The line is only valid until the
+// next call to scan. If the scanner has a maximum length, only the first max bytes of a longer line are
+// returned, without its line terminator, and the remainder is discarded. At the end of the input scan
+// returns io.EOF.
+func (s *lineScanner) scan() ([]byte, error) {
+	if s.r == nil {
+		return s.scanBytes()
+	}
+	if s.rest {
+		if err := s.discardLine(); err != nil {
+			return nil, err
+		}
+	}
+	s.long = s.long[:0]
+	s.offset = s.next
+	cr := false // whether the part of the line already read ended with a carriage return
+	for {
+		buf, err := s.peek()
+		if err != nil {
+			if err == io.EOF && s.next > s.offset {
+				// the final line is not terminated
+				return s.done(nil), nil
+			}
+			return nil, err
+		}
+
+		if cr {
+			if buf[0] == '\n' {
+				return s.done(buf[:1]), nil
+			}
+			return s.done(nil), nil
+		}
+		end := lineEnd(buf)
+		if s.max > 0 {
+			n := len(buf) // the number of bytes of the line in buf, excluding its terminator
+			if end > 0 {
+				n = end - terminatorLen(buf[:end])
+			}
+			if len(s.long)+n > s.max {
+				s.rest = true
+				return s.done(buf[:s.max-len(s.long)]), nil
+			}
+		}
+		if end > 0 {
+			return s.done(buf[:end]), nil
+		}
+
+		if len(buf) < s.r.Size() {
+			// Filling the buffer may move its contents, so the line is peeked again
+			if buf, err := s.r.Peek(len(buf) + 1); err == io.EOF {
+				return s.done(buf), nil
+			} else if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+				return nil, err
+			}
+			continue
+		}
+
+		// The line is longer than the buffer
+		s.long = append(s.long, buf...)
+		cr = buf[len(buf)-1] == '\r'
+		s.discard(len(buf))
+	}
+}
+
+// scanBytes returns the next line of an input held in s.data, as described by scan. The line is a
+// sub-slice of s.data.
+func (s *lineScanner) scanBytes() ([]byte, error) {
+	rest := s.data[s.next:]
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	end := lineEnd(rest)
+	if end == 0 {
+		// the final line is not terminated or ends with a carriage return
+		end = len(rest)
+	}
+	line := rest[:end]
+	s.n++
+	s.offset = s.next
+	s.next += int64(end)
+	if s.max > 0 && len(line)-terminatorLen(line) > s.max {
+		line = line[:s.max]
+	}
+	return line, nil
+}
+
+// peek returns the buffered bytes of the input, filling the buffer if it is empty.
+func (s *lineScanner) peek() ([]byte, error) {
+	if s.r.Buffered() == 0 {
+		if _, err := s.r.Peek(1); err != nil {
+			return nil, err
+		}
+	}
+	return s.r.Peek(s.r.Buffered())
+}
+
+// discard discards n buffered bytes of the input.
+func (s *lineScanner) discard(n int) {
+	s.r.Discard(n)
+	s.next += int64(n)
+}
+
+// done completes the scan of a line that ends with part, which is discarded from the input, returning the
+// line.
+func (s *lineScanner) done(part []byte) []byte {
+	s.discard(len(part))
+	s.n++
+	if s.next-s.offset > int64(len(part)) {
+		s.long = append(s.long, part...)
+		return s.long
+	}
+	return part
+}
+
+// discardLine discards the remainder of a line that was too long to be returned in full.
+func (s *lineScanner) discardLine() error {
+	cr := false
+	for {
+		buf, err := s.peek()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if cr {
+			if buf[0] == '\n' {
+				s.discard(1)
+			}
+			break
+		}
+		if end := lineEnd(buf); end > 0 {
+			s.discard(end)
+			break
+		}
+		cr = buf[len(buf)-1] == '\r'
+		s.discard(len(buf))
+	}
+	s.rest = false
+	return nil
+}
+
+// terminatorLen returns the length of the line terminator at the end of line.
+func terminatorLen(line []byte) int {
+	switch {
+	case bytes.HasSuffix(line, []byte("\r\n")):
+		return 2
+	case bytes.HasSuffix(line, []byte("\n")), bytes.HasSuffix(line, []byte("\r")):
+		return 1
+	}
+	return 0
+}
+
+// lineEnd returns the length of the line at the start of buf, including its line terminator, or zero if buf
+// does not hold the whole line.
+func lineEnd(buf []byte) int {
+	i := bytes.IndexByte(buf, '\n')
+	limit := i
+	if i < 0 {
+		limit = len(buf)
+	}
+	if j := bytes.IndexByte(buf[:limit], '\r'); j >= 0 {
+		if j+1 == i {
+			return i + 1
+		}
+		if j+1 < len(buf) {
+			// a lone carriage return
+			return j + 1
+		}
+		// a carriage return at the end of buf may be followed by a line feed
+		return 0
+	}
+	return i + 1
+}
+
+// newStatementReader returns a new Reader, configured using the supplied options, that parses the statement
+// or term held in b rather than reading from an input. The contents of b must not be modified while the
+// Reader is in use.
+func newStatementReader(b []byte, opts ...Option) *Reader {
+	r := newReader(opts)
+	r.r.reset(b)
+	return r
+}
+
+// A lineReader reads the bytes of a single line.
+type lineReader struct {
+	b    []byte
+	i    int
+	last int    // the size of the last rune read, or -1 if it cannot be unread
+	s    string // a copy of b, made when first needed by text
+}
+
+func (l *lineReader) reset(b []byte) {
+	l.b = b
+	l.i = 0
+	l.last = -1
+	l.s = ""
+}
+
+// text returns the bytes of the line from start to end as a string. The line is copied once, so the strings
+// of all the terms in a statement share a single allocation.
+func (l *lineReader) text(start, end int) string {
+	if l.s == "" {
+		l.s = string(l.b)
+	}
+	return l.s[start:end]
+}
+
+func (l *lineReader) ReadByte() (byte, error) {
+	if l.i >= len(l.b) {
+		l.last = -1
+		return 0, io.EOF
+	}
+	b := l.b[l.i]
+	l.i++
+	l.last = 1
+	return b, nil
+}
+
+func (l *lineReader) UnreadByte() error {
+	if l.i == 0 {
+		return bufio.ErrInvalidUnreadByte
+	}
+	l.i--
+	l.last = -1
+	return nil
+}
+
+func (l *lineReader) ReadRune() (rune, int, error) {
+	if l.i >= len(l.b) {
+		l.last = -1
+		return 0, 0, io.EOF
+	}
+	r1, size := rune(l.b[l.i]), 1
+	if r1 >= utf8.RuneSelf {
+		r1, size = utf8.DecodeRune(l.b[l.i:])
+	}
+	l.i += size
+	l.last = size
+	return r1, size, nil
+}
+
+func (l *lineReader) UnreadRune() error {
+	if l.last < 0 {
+		return bufio.ErrInvalidUnreadRune
+	}
+	l.i -= l.last
+	l.last = -1
+	return nil
+}
+
+// Peek returns the next n bytes without advancing the reader, or the remainder of the line and io.EOF if
+// fewer than n bytes remain.
+func (l *lineReader) Peek(n int) ([]byte, error) {
+	if rest := l.b[l.i:]; len(rest) < n {
+		return rest, io.EOF
+	}
+	return l.b[l.i : l.i+n], nil
+}
+
+// Buffered returns the number of bytes of the line remaining to be read.
+func (l *lineReader) Buffered() int {
+	return len(l.b) - l.i
+}
+
+// Discard skips the next n bytes, returning the number of bytes discarded.
+func (l *lineReader) Discard(n int) (int, error) {
+	n = min(n, len(l.b)-l.i)
+	l.i += n
+	l.last = -1
+	return n, nil
+}
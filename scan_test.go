@@ -0,0 +1,116 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineScanner(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	testCases := []struct {
+		name    string
+		input   string
+		max     int
+		want    []string
+		offsets []int64
+	}{
+		{
+			name:    "lf",
+			input:   "a\nbc\n\nd",
+			want:    []string{"a\n", "bc\n", "\n", "d"},
+			offsets: []int64{0, 2, 5, 6},
+		},
+		{
+			name:    "crlf and cr",
+			input:   "a\r\nb\rc\r\r\nd\r",
+			want:    []string{"a\r\n", "b\r", "c\r", "\r\n", "d\r"},
+			offsets: []int64{0, 3, 5, 7, 9},
+		},
+		{
+			name:    "longer than buffer",
+			input:   long + "\n" + long + "\r\n" + long,
+			want:    []string{long + "\n", long + "\r\n", long},
+			offsets: []int64{0, 41, 83},
+		},
+		{
+			name:    "carriage return at end of buffer",
+			input:   strings.Repeat("x", 15) + "\r\ny\n" + strings.Repeat("x", 15) + "\rz",
+			want:    []string{strings.Repeat("x", 15) + "\r\n", "y\n", strings.Repeat("x", 15) + "\r", "z"},
+			offsets: []int64{0, 17, 19, 35},
+		},
+		{
+			name:    "truncated",
+			input:   "abc\n" + long + "\r\nde\r\n" + long,
+			max:     4,
+			want:    []string{"abc\n", "xxxx", "de\r\n", "xxxx"},
+			offsets: []int64{0, 4, 46, 50},
+		},
+		{
+			name:    "within limit",
+			input:   "abcd\r\nabcde\n",
+			max:     4,
+			want:    []string{"abcd\r\n", "abcd"},
+			offsets: []int64{0, 6},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := lineScanner{r: bufio.NewReaderSize(strings.NewReader(tc.input), 16), max: tc.max}
+			var got []string
+			var offsets []int64
+			for {
+				line, err := s.scan()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("got unexpected error %q", err)
+				}
+				got = append(got, string(line))
+				offsets = append(offsets, s.offset)
+				if s.n != len(got) {
+					t.Errorf("got line number %d, wanted %d", s.n, len(got))
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got lines %q, wanted %q", got, tc.want)
+			}
+			if !reflect.DeepEqual(offsets, tc.offsets) {
+				t.Errorf("got offsets %v, wanted %v", offsets, tc.offsets)
+			}
+		})
+	}
+}
+
+func TestLongStatements(t *testing.T) {
+	value := strings.Repeat("long literal value ", 20)
+	input := "<http://example/s> <http://example/p> \"" + value + "\" .\r\n" +
+		"<http://example/s> <http://example/p> \"short\" .\n"
+
+	r := NewReaderSize(strings.NewReader(input), 16)
+	var got []Quad
+	for r.Next() {
+		got = append(got, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d quads, wanted 2", len(got))
+	}
+	if got[0].O.Value != value || got[1].O.Value != "short" {
+		t.Errorf("got objects %q and %q", got[0].O.Value, got[1].O.Value)
+	}
+	if line, _, _ := r.Position(); line != 2 {
+		t.Errorf("got last statement on line %d, wanted 2", line)
+	}
+}
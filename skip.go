@@ -0,0 +1,50 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"io"
+)
+
+// Skip advances past the next n statements without returning them, which is cheaper than calling Next n
+// times. The statements are checked for syntax errors but their terms are not constructed, so errors that
+// depend on the values of terms, such as relative IRIs, are not reported. Statements are counted whether or
+// not they would be returned by the filters configured for the Reader and do not count towards the limit set
+// by WithMaxQuads.
+//
+// Skip returns io.EOF if the end of the input is reached before n statements have been skipped. Any other
+// error is also returned by Err and stops the Reader. If the Reader was configured to skip invalid
+// statements, those statements are skipped and recorded as usual but not counted.
+func (r *Reader) Skip(n int) error {
+	_, err := r.skipStatements(n)
+	return err
+}
+
+// skipStatements skips up to n statements, returning the number skipped.
+func (r *Reader) skipStatements(n int) (int, error) {
+	r.q = &r.quad
+	r.discard = true
+	defer func() {
+		r.discard = false
+		r.quad = Quad{}
+	}()
+
+	skipped := 0
+	for skipped < n {
+		if r.readStatement() {
+			skipped++
+			continue
+		}
+		if r.skipInvalid && r.skip() {
+			continue
+		}
+		if r.err != nil {
+			return skipped, r.err
+		}
+		return skipped, io.EOF
+	}
+	return skipped, nil
+}
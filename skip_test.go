@@ -0,0 +1,106 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSkip(t *testing.T) {
+	input := "# header\n" +
+		"<http://example/s> <http://example/p> \"0\" .\n" +
+		"<< <http://example/s> <http://example/p> _:o >> <http://example/p> \"1\"@en--ltr <http://example/g> .\n" +
+		"\n" +
+		"_:b <http://example/p> \"2\"^^<http://example/dt> . # comment\n" +
+		"<relative> <http://example/p> \"3\" .\n" +
+		"<http://example/s> <http://example/p> \"4\" .\n" +
+		"<http://example/s> <http://example/p> \"5\" .\n"
+
+	testCases := []struct {
+		n    int
+		want []string
+		err  error
+	}{
+		{n: 0, want: []string{"0", "1", "2"}, err: ErrRelativeIRI},
+		{n: 1, want: []string{"1", "2"}, err: ErrRelativeIRI},
+		{n: 4, want: []string{"4", "5"}},
+		{n: 6, want: nil},
+		{n: 7, err: io.EOF},
+	}
+
+	for _, tc := range testCases {
+		r := NewReader(strings.NewReader(input))
+		err := r.Skip(tc.n)
+		if tc.err == io.EOF {
+			if err != io.EOF {
+				t.Errorf("skip %d: got error %v, wanted %v", tc.n, err, io.EOF)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("skip %d: got unexpected error %q", tc.n, err)
+			continue
+		}
+		var got []string
+		for r.Next() {
+			got = append(got, r.Quad().O.Value)
+		}
+		if !errors.Is(r.Err(), tc.err) {
+			t.Errorf("skip %d: got error %v, wanted %v", tc.n, r.Err(), tc.err)
+		}
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("skip %d: got %q, wanted %q", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestSkipSyntaxError(t *testing.T) {
+	input := "<http://example/s> <http://example/p> \"0\" .\n" +
+		"<http://example/s> <http://example/p> \"1\"@en- .\n" +
+		"<http://example/s> <http://example/p> \"2\" .\n"
+
+	r := NewReader(strings.NewReader(input))
+	err := r.Skip(3)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, wanted a ParseError", err)
+	}
+	if perr.Line != 2 || perr.Column != 44 {
+		t.Errorf("got error at line %d column %d, wanted line 2 column 44", perr.Line, perr.Column)
+	}
+	if r.Err() != err {
+		t.Errorf("got Err %v, wanted %v", r.Err(), err)
+	}
+
+	r = NewReader(strings.NewReader(input), WithErrorCollection(0))
+	if err := r.Skip(3); err != io.EOF {
+		t.Errorf("got error %v, wanted %v", err, io.EOF)
+	}
+	if len(r.Errors()) != 1 {
+		t.Errorf("got %d errors, wanted 1", len(r.Errors()))
+	}
+}
+
+func TestSkipFilters(t *testing.T) {
+	input := "<http://example/a> <http://example/p> \"0\" .\n" +
+		"<http://example/b> <http://example/p> \"1\" .\n" +
+		"<http://example/a> <http://example/p> \"2\" .\n"
+
+	r := NewReader(strings.NewReader(input), WithSubjects("http://example/a"), WithMaxQuads(1))
+	if err := r.Skip(1); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	var got []string
+	for r.Next() {
+		got = append(got, r.Quad().O.Value)
+	}
+	if strings.Join(got, "|") != "2" {
+		t.Errorf("got %q, wanted [2]", got)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// A SortedWriter buffers quads in memory and writes them using a Writer in the order described by
+// CompareQuads, so the same quads always produce byte-identical output regardless of the order in which
+// they were written. Duplicate quads are written once.
+//
+// Quads are only written when Flush is called, so Flush should normally be called once after all quads have
+// been written. Each call to Flush writes the quads buffered since the previous call as a separately sorted
+// sequence.
+type SortedWriter struct {
+	w     *Writer
+	quads []Quad
+}
+
+// NewSortedWriter returns a new SortedWriter that writes to w.
+func NewSortedWriter(w *Writer) *SortedWriter {
+	return &SortedWriter{w: w}
+}
+
+// Write adds q to the quads buffered for the next call to Flush. It never returns an error.
+func (s *SortedWriter) Write(q Quad) error {
+	s.quads = append(s.quads, q)
+	return nil
+}
+
+// Flush sorts the buffered quads, writes them to the underlying Writer and flushes it. To check if an error
+// occurred during the Flush, call Error.
+func (s *SortedWriter) Flush() {
+	sortQuads(s.quads)
+	for i, q := range s.quads {
+		if i > 0 && q == s.quads[i-1] {
+			continue
+		}
+		if err := s.w.Write(q); err != nil {
+			break
+		}
+	}
+	s.quads = s.quads[:0]
+	s.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Flush.
+func (s *SortedWriter) Error() error {
+	return s.w.Error()
+}
@@ -0,0 +1,69 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSortedWriter(t *testing.T) {
+	inputs := []string{
+		datasetInput,
+		`<http://example/s1> <http://example/p> "z" .
+<http://example/s1> <http://example/p> <http://example/o> .
+<http://example/s1> <http://example/p> "a" .
+<http://example/s2> <http://example/p> "b" .
+<http://example/s1> <http://example/p> "a"@en .
+<http://example/s1> <http://example/p> "z" <http://example/g> .
+_:b1 <http://example/p> <http://example/o> <http://example/g> .
+`,
+	}
+
+	want := `<http://example/s1> <http://example/p> "a" .
+<http://example/s1> <http://example/p> "a"@en .
+<http://example/s1> <http://example/p> "z" .
+<http://example/s1> <http://example/p> "z" <http://example/g> .
+<http://example/s1> <http://example/p> <http://example/o> .
+<http://example/s2> <http://example/p> "b" .
+_:b1 <http://example/p> <http://example/o> <http://example/g> .
+`
+
+	for _, input := range inputs {
+		var buf bytes.Buffer
+		w := NewSortedWriter(NewWriter(&buf))
+		r := NewReader(strings.NewReader(input))
+		for r.Next() {
+			if err := w.Write(r.Quad()); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+		}
+		if r.Err() != nil {
+			t.Fatalf("got unexpected error %q", r.Err())
+		}
+		if buf.Len() != 0 {
+			t.Errorf("got output before Flush")
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+
+		if got := buf.String(); got != want {
+			t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+		}
+	}
+}
+
+func TestSortedWriterError(t *testing.T) {
+	w := NewSortedWriter(NewWriter(failingWriter{}))
+	w.Write(messageCases[0].quad)
+	w.Flush()
+	if w.Error() == nil {
+		t.Errorf("got no error, wanted one")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// sqliteSchema creates the table used by ExportSQLite. Each term is stored using its N-Quads
+// serialization so that, for example, a predicate can be matched with
+// WHERE predicate = '<http://xmlns.com/foaf/0.1/name>'. The graph column is empty for quads
+// in the default graph.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS quads (subject TEXT NOT NULL, predicate TEXT NOT NULL, object TEXT NOT NULL, graph TEXT NOT NULL DEFAULT '')`,
+	`CREATE INDEX IF NOT EXISTS quads_spo ON quads (subject, predicate, object)`,
+	`CREATE INDEX IF NOT EXISTS quads_po ON quads (predicate, object)`,
+	`CREATE INDEX IF NOT EXISTS quads_o ON quads (object)`,
+	`CREATE INDEX IF NOT EXISTS quads_g ON quads (graph)`,
+}
+
+// ExportSQLite reads all quads from r and inserts them into a table called quads in db, creating the table
+// and its indexes if they do not already exist. The quads are inserted in a single transaction which is
+// rolled back if an error is encountered. It returns the number of quads inserted. The caller is responsible
+// for opening db using an SQLite driver.
+func ExportSQLite(db *sql.DB, r *Reader) (int64, error) {
+	for _, stmt := range sqliteSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return 0, fmt.Errorf("create schema: %w", err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert, err := tx.Prepare(`INSERT INTO quads (subject, predicate, object, graph) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare insert: %w", err)
+	}
+	defer insert.Close()
+
+	var n int64
+	for r.Next() {
+		q := r.Quad()
+		if _, err := insert.Exec(termText(q.S), termText(q.P), termText(q.O), termText(q.G)); err != nil {
+			return 0, fmt.Errorf("insert quad: %w", err)
+		}
+		n++
+	}
+	if r.Err() != nil {
+		return 0, r.Err()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return n, nil
+}
+
+// ImportSQLite reads the quads stored in db by ExportSQLite, calling fn for each one in insertion order.
+// Iteration stops at the first error returned by fn.
+func ImportSQLite(db *sql.DB, fn func(Quad) error) error {
+	rows, err := db.Query(`SELECT subject, predicate, object, graph FROM quads ORDER BY rowid`)
+	if err != nil {
+		return fmt.Errorf("query quads: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s, p, o, g string
+		if err := rows.Scan(&s, &p, &o, &g); err != nil {
+			return fmt.Errorf("scan quad: %w", err)
+		}
+
+		var q Quad
+		if q.S, err = ParseTerm(s); err != nil {
+			return fmt.Errorf("subject %q: %w", s, err)
+		}
+		if q.P, err = ParseTerm(p); err != nil {
+			return fmt.Errorf("predicate %q: %w", p, err)
+		}
+		if q.O, err = ParseTerm(o); err != nil {
+			return fmt.Errorf("object %q: %w", o, err)
+		}
+		if g != "" {
+			if q.G, err = ParseTerm(g); err != nil {
+				return fmt.Errorf("graph %q: %w", g, err)
+			}
+		}
+
+		if err := fn(q); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
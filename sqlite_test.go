@@ -0,0 +1,115 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+)
+
+// tableDriver is a minimal database/sql driver that understands just enough of the statements used by
+// ExportSQLite and ImportSQLite to store rows in memory.
+type tableDriver struct {
+	rows [][]driver.Value
+}
+
+func (d *tableDriver) Open(name string) (driver.Conn, error) { return &tableConn{d: d}, nil }
+
+type tableConn struct{ d *tableDriver }
+
+func (c *tableConn) Prepare(query string) (driver.Stmt, error) {
+	return &tableStmt{d: c.d, query: query}, nil
+}
+func (c *tableConn) Close() error              { return nil }
+func (c *tableConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *tableConn) Commit() error             { return nil }
+func (c *tableConn) Rollback() error           { return nil }
+
+type tableStmt struct {
+	d     *tableDriver
+	query string
+}
+
+func (s *tableStmt) Close() error  { return nil }
+func (s *tableStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s *tableStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.rows = append(s.d.rows, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *tableStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &tableRows{rows: s.d.rows}, nil
+}
+
+type tableRows struct {
+	rows [][]driver.Value
+}
+
+func (r *tableRows) Columns() []string { return []string{"subject", "predicate", "object", "graph"} }
+func (r *tableRows) Close() error      { return nil }
+
+func (r *tableRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestSQLiteRoundTrip(t *testing.T) {
+	input := `<http://example/s> <http://example/p> "chat"@fr <http://example/g> .
+_:b1 <http://example/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example/s> <http://example/p> "a \"quoted\"\nvalue" _:g .
+`
+	var want []Quad
+	r := NewReader(strings.NewReader(input))
+	for r.Next() {
+		want = append(want, r.Quad())
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+
+	sql.Register("nquads-test-table", &tableDriver{})
+	db, err := sql.Open("nquads-test-table", "")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	n, err := ExportSQLite(db, NewReader(strings.NewReader(input)))
+	if err != nil {
+		t.Fatalf("export: got unexpected error %q", err)
+	}
+	if n != int64(len(want)) {
+		t.Errorf("export: got %d quads, wanted %d", n, len(want))
+	}
+
+	var got []Quad
+	err = ImportSQLite(db, func(q Quad) error {
+		got = append(got, q)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("import: got unexpected error %q", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("import: got %d quads, wanted %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("quad %d: got %s, wanted %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/iand/gordf"
+)
+
+// LanguageStats reports on the literals used as objects in a stream of quads, broken down by language.
+// Language tags are compared case-insensitively and reported in lowercase.
+type LanguageStats struct {
+	PlainLiterals  int // literals with neither a language tag nor a datatype
+	TypedLiterals  int // literals with a datatype
+	TaggedLiterals int // literals with a language tag
+
+	langs map[string]*languageUsage
+}
+
+type languageUsage struct {
+	statements int
+	predicates map[rdf.Term]struct{}
+}
+
+// A LanguageCount reports how often a language was used.
+type LanguageCount struct {
+	Language   string // the lowercase language tag
+	Statements int    // the number of statements with a literal object in the language
+	Predicates int    // the number of distinct predicates used with literals in the language
+}
+
+// CollectLanguageStats reads all quads from r and returns statistics about the literals they contain.
+func CollectLanguageStats(r *Reader) (*LanguageStats, error) {
+	s := &LanguageStats{}
+	for r.Next() {
+		s.Add(r.Quad())
+	}
+	return s, r.Err()
+}
+
+// Add updates the statistics with the object of q if it is a literal.
+func (s *LanguageStats) Add(q Quad) {
+	if !isLiteral(q.O) {
+		return
+	}
+
+	switch {
+	case q.O.Language != "":
+		s.TaggedLiterals++
+	case q.O.Datatype != "":
+		s.TypedLiterals++
+		return
+	default:
+		s.PlainLiterals++
+		return
+	}
+
+	if s.langs == nil {
+		s.langs = make(map[string]*languageUsage)
+	}
+	lang := strings.ToLower(q.O.Language)
+	u, ok := s.langs[lang]
+	if !ok {
+		u = &languageUsage{predicates: make(map[rdf.Term]struct{})}
+		s.langs[lang] = u
+	}
+	u.statements++
+	u.predicates[q.P] = struct{}{}
+}
+
+// Languages returns the usage of each language, ordered by descending number of statements. Languages with
+// equal numbers of statements are ordered lexically.
+func (s *LanguageStats) Languages() []LanguageCount {
+	counts := make([]LanguageCount, 0, len(s.langs))
+	for lang, u := range s.langs {
+		counts = append(counts, LanguageCount{
+			Language:   lang,
+			Statements: u.statements,
+			Predicates: len(u.predicates),
+		})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Statements != counts[j].Statements {
+			return counts[i].Statements > counts[j].Statements
+		}
+		return counts[i].Language < counts[j].Language
+	})
+	return counts
+}
@@ -0,0 +1,45 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLanguageStats(t *testing.T) {
+	input := `<http://example/s> <http://example/label> "chat"@fr .
+<http://example/s> <http://example/label> "cat"@en .
+<http://example/s> <http://example/comment> "a cat"@EN .
+<http://example/s> <http://example/label> "cat"@en-GB .
+<http://example/s> <http://example/name> "Tom" .
+<http://example/s> <http://example/age> "3"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example/s> <http://example/knows> <http://example/o> .
+`
+	s, err := CollectLanguageStats(NewReader(strings.NewReader(input)))
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	if s.PlainLiterals != 1 || s.TypedLiterals != 1 || s.TaggedLiterals != 4 {
+		t.Errorf("got plain=%d typed=%d tagged=%d, wanted plain=1 typed=1 tagged=4", s.PlainLiterals, s.TypedLiterals, s.TaggedLiterals)
+	}
+
+	want := []LanguageCount{
+		{Language: "en", Statements: 2, Predicates: 2},
+		{Language: "en-gb", Statements: 1, Predicates: 1},
+		{Language: "fr", Statements: 1, Predicates: 1},
+	}
+	got := s.Languages()
+	if len(got) != len(want) {
+		t.Fatalf("got %d languages %v, wanted %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("language %d: got %v, wanted %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,218 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"hash/maphash"
+	"sort"
+
+	"github.com/iand/gordf"
+)
+
+// The dimensions of the count-min sketch used by a Tally once it holds MaxTerms terms of a kind. Estimates
+// exceed the true count by at most 2/sketchWidth of the number of occurrences counted by the sketch, with
+// probability 1-(1/2)^sketchDepth.
+const (
+	sketchDepth = 4
+	sketchWidth = 2048
+)
+
+// A Tally counts the occurrences of distinct subjects, predicates, classes and graphs in a stream of quads.
+// Classes are the objects of rdf:type statements. Graphs are counted for quads in named graphs only.
+//
+// If MaxTerms is positive, at most that many distinct terms of each kind are counted exactly, which bounds
+// the memory used when reading inputs with very many distinct subjects. Terms first seen once the limit has
+// been reached are counted approximately using a count-min sketch: Count returns an estimate for them, which
+// may exceed the true count but never falls short of it, and they are not listed by Subjects, Predicates,
+// Classes or Graphs. MaxTerms can be changed before the first call to Add.
+type Tally struct {
+	MaxTerms int   // the maximum number of distinct terms of each kind counted exactly, unlimited if zero
+	Quads    int64 // the number of quads added
+
+	subjects   termCounter
+	predicates termCounter
+	classes    termCounter
+	graphs     termCounter
+}
+
+// A TermKind selects the terms counted by a Tally.
+type TermKind int
+
+const (
+	SubjectTerms   TermKind = iota // the subjects of quads
+	PredicateTerms                 // the predicates of quads
+	ClassTerms                     // the objects of rdf:type statements
+	GraphTerms                     // the graphs of quads in named graphs
+)
+
+// A TermCount reports how often a term was used.
+type TermCount struct {
+	Term  rdf.Term
+	Count int64
+}
+
+// CollectTally reads all quads from r and returns a Tally of the terms they contain, counting at most max
+// distinct terms of each kind exactly as described by Tally.
+func CollectTally(r *Reader, max int) (*Tally, error) {
+	t := &Tally{MaxTerms: max}
+	for r.Next() {
+		t.Add(r.Quad())
+	}
+	return t, r.Err()
+}
+
+// Add counts the terms of q.
+func (t *Tally) Add(q Quad) {
+	t.Quads++
+	t.subjects.add(q.S, t.MaxTerms)
+	t.predicates.add(q.P, t.MaxTerms)
+	if q.P.Kind == rdf.IRITerm && q.P.Value == rdfType {
+		t.classes.add(q.O, t.MaxTerms)
+	}
+	if q.G.Kind != rdf.UnknownTerm {
+		t.graphs.add(q.G, t.MaxTerms)
+	}
+}
+
+// Count returns the number of times term has been counted as the given kind of term.
+func (t *Tally) Count(kind TermKind, term rdf.Term) int64 {
+	if c := t.counter(kind); c != nil {
+		return c.count(term)
+	}
+	return 0
+}
+
+// Distinct returns the number of distinct terms of the given kind counted exactly, and whether any terms of
+// that kind were counted approximately because MaxTerms was reached.
+func (t *Tally) Distinct(kind TermKind) (n int, approximate bool) {
+	if c := t.counter(kind); c != nil {
+		return len(c.exact), c.sketch != nil
+	}
+	return 0, false
+}
+
+// Subjects returns the subjects counted exactly, ordered by descending count.
+func (t *Tally) Subjects() []TermCount { return t.subjects.counts() }
+
+// Predicates returns the predicates counted exactly, ordered by descending count.
+func (t *Tally) Predicates() []TermCount { return t.predicates.counts() }
+
+// Classes returns the classes counted exactly, ordered by descending count.
+func (t *Tally) Classes() []TermCount { return t.classes.counts() }
+
+// Graphs returns the graphs counted exactly, ordered by descending count.
+func (t *Tally) Graphs() []TermCount { return t.graphs.counts() }
+
+func (t *Tally) counter(kind TermKind) *termCounter {
+	switch kind {
+	case SubjectTerms:
+		return &t.subjects
+	case PredicateTerms:
+		return &t.predicates
+	case ClassTerms:
+		return &t.classes
+	case GraphTerms:
+		return &t.graphs
+	}
+	return nil
+}
+
+// A termCounter counts occurrences of terms, exactly for up to a limited number of distinct terms and
+// approximately for the rest.
+type termCounter struct {
+	exact  map[rdf.Term]int64
+	sketch *countMinSketch // counts the terms not held in exact, created once exact is full
+}
+
+func (c *termCounter) add(t rdf.Term, max int) {
+	if n, ok := c.exact[t]; ok {
+		c.exact[t] = n + 1
+		return
+	}
+	if max <= 0 || len(c.exact) < max {
+		if c.exact == nil {
+			c.exact = make(map[rdf.Term]int64)
+		}
+		c.exact[t] = 1
+		return
+	}
+	if c.sketch == nil {
+		c.sketch = newCountMinSketch()
+	}
+	c.sketch.add(t)
+}
+
+func (c *termCounter) count(t rdf.Term) int64 {
+	if n, ok := c.exact[t]; ok {
+		return n
+	}
+	if c.sketch != nil {
+		return c.sketch.estimate(t)
+	}
+	return 0
+}
+
+// counts returns the terms counted exactly, ordered by descending count. Terms with equal counts are
+// ordered as by CompareTerms.
+func (c *termCounter) counts() []TermCount {
+	counts := make([]TermCount, 0, len(c.exact))
+	for t, n := range c.exact {
+		counts = append(counts, TermCount{Term: t, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return CompareTerms(counts[i].Term, counts[j].Term) < 0
+	})
+	return counts
+}
+
+// A countMinSketch estimates the number of occurrences of terms in fixed space.
+type countMinSketch struct {
+	seed   maphash.Seed
+	counts [sketchDepth][sketchWidth]int64
+}
+
+func newCountMinSketch() *countMinSketch {
+	return &countMinSketch{seed: maphash.MakeSeed()}
+}
+
+// indexes returns the counter used for t in each row, derived from a single hash of t.
+func (s *countMinSketch) indexes(t rdf.Term) [sketchDepth]int {
+	var h maphash.Hash
+	h.SetSeed(s.seed)
+	h.WriteByte(byte(t.Kind))
+	h.WriteString(t.Value)
+	h.WriteByte(0)
+	h.WriteString(t.Language)
+	h.WriteByte(0)
+	h.WriteString(t.Datatype)
+	sum := h.Sum64()
+
+	h1, h2 := sum&0xFFFFFFFF, sum>>32|1
+	var idx [sketchDepth]int
+	for i := range idx {
+		idx[i] = int((h1 + uint64(i)*h2) % sketchWidth)
+	}
+	return idx
+}
+
+func (s *countMinSketch) add(t rdf.Term) {
+	for i, j := range s.indexes(t) {
+		s.counts[i][j]++
+	}
+}
+
+func (s *countMinSketch) estimate(t rdf.Term) int64 {
+	var min int64 = -1
+	for i, j := range s.indexes(t) {
+		if n := s.counts[i][j]; min < 0 || n < min {
+			min = n
+		}
+	}
+	return min
+}
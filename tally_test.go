@@ -0,0 +1,97 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestTally(t *testing.T) {
+	input := `<http://example/a> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Cat> <http://example/g> .
+<http://example/b> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Cat> .
+<http://example/a> <http://example/name> "Tom" <http://example/g> .
+_:c <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example/Dog> <http://example/g> .
+<http://example/a> <http://example/knows> _:c .
+`
+	tally, err := CollectTally(NewReader(strings.NewReader(input)), 0)
+	if err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if tally.Quads != 5 {
+		t.Errorf("got %d quads, wanted 5", tally.Quads)
+	}
+
+	testCases := []struct {
+		name string
+		got  []TermCount
+		want []TermCount
+	}{
+		{
+			name: "subjects",
+			got:  tally.Subjects(),
+			want: []TermCount{{rdf.IRI("http://example/a"), 3}, {rdf.IRI("http://example/b"), 1}, {rdf.Blank("c"), 1}},
+		},
+		{
+			name: "predicates",
+			got:  tally.Predicates(),
+			want: []TermCount{{rdf.IRI(rdfType), 3}, {rdf.IRI("http://example/knows"), 1}, {rdf.IRI("http://example/name"), 1}},
+		},
+		{
+			name: "classes",
+			got:  tally.Classes(),
+			want: []TermCount{{rdf.IRI("http://example/Cat"), 2}, {rdf.IRI("http://example/Dog"), 1}},
+		},
+		{
+			name: "graphs",
+			got:  tally.Graphs(),
+			want: []TermCount{{rdf.IRI("http://example/g"), 3}},
+		},
+	}
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("%s: got %v, wanted %v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	if n := tally.Count(ClassTerms, rdf.IRI("http://example/Cat")); n != 2 {
+		t.Errorf("got class count %d, wanted 2", n)
+	}
+	if n, approximate := tally.Distinct(SubjectTerms); n != 3 || approximate {
+		t.Errorf("got %d distinct subjects, approximate %v, wanted 3 exact", n, approximate)
+	}
+}
+
+func TestTallyMaxTerms(t *testing.T) {
+	tally := &Tally{MaxTerms: 10}
+	for i := 0; i < 1000; i++ {
+		s := rdf.IRI(fmt.Sprintf("http://example/s%d", i%100))
+		tally.Add(Quad{S: s, P: rdf.IRI("http://example/p"), O: rdf.Literal(fmt.Sprint(i))})
+	}
+
+	if n, approximate := tally.Distinct(SubjectTerms); n != 10 || !approximate {
+		t.Errorf("got %d distinct subjects, approximate %v, wanted 10 approximate", n, approximate)
+	}
+	if n, approximate := tally.Distinct(PredicateTerms); n != 1 || approximate {
+		t.Errorf("got %d distinct predicates, approximate %v, wanted 1 exact", n, approximate)
+	}
+	if len(tally.Subjects()) != 10 {
+		t.Errorf("got %d subjects listed, wanted 10", len(tally.Subjects()))
+	}
+	for i := 0; i < 100; i++ {
+		// estimates never fall short of the true count
+		if n := tally.Count(SubjectTerms, rdf.IRI(fmt.Sprintf("http://example/s%d", i))); n < 10 {
+			t.Errorf("got count %d for subject %d, wanted at least 10", n, i)
+		}
+	}
+	if n := tally.Count(SubjectTerms, rdf.IRI("http://example/s5")); n != 10 {
+		t.Errorf("got exact count %d, wanted 10", n)
+	}
+}
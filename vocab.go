@@ -0,0 +1,29 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+// IRIs of terms from well known vocabularies used by this package.
+const (
+	rdfNS         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	rdfType       = rdfNS + "type"
+	rdfLangString = rdfNS + "langString"
+
+	xsdNS            = "http://www.w3.org/2001/XMLSchema#"
+	xsdString        = xsdNS + "string"
+	xsdBoolean       = xsdNS + "boolean"
+	xsdInteger       = xsdNS + "integer"
+	xsdDecimal       = xsdNS + "decimal"
+	xsdDouble        = xsdNS + "double"
+	xsdFloat         = xsdNS + "float"
+	xsdDate          = xsdNS + "date"
+	xsdDateTime      = xsdNS + "dateTime"
+	xsdDateTimeStamp = xsdNS + "dateTimeStamp"
+
+	shNS      = "http://www.w3.org/ns/shacl#"
+	voidNS    = "http://rdfs.org/ns/void#"
+	dctermsNS = "http://purl.org/dc/terms/"
+	schemaNS  = "http://schema.org/"
+)
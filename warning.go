@@ -0,0 +1,91 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// These are the deviations from good practice reported in Warning.Err.
+var (
+	// ErrMalformedLanguageTag is reported for a language tag that is not well-formed according to BCP 47,
+	// such as one with a primary language subtag that is too long.
+	ErrMalformedLanguageTag = errors.New("language tag is not well-formed")
+
+	// ErrNonCanonicalEscape is reported for a \u or \U escape of a character that could have been written
+	// directly or, in a literal, using a shorter escape such as \n.
+	ErrNonCanonicalEscape = errors.New("unnecessary escape sequence")
+
+	// ErrUnwiseIRI is reported for an IRI that is permitted by N-Quads but does not conform to RFC 3987, for
+	// example because it contains a space or a character such as '{' that was written using an escape.
+	ErrUnwiseIRI = errors.New("IRI does not conform to RFC 3987")
+)
+
+// A Warning describes a deviation from good practice in the input that does not prevent it from being read.
+// The first line is 1. The first column is 0.
+type Warning struct {
+	Line   int   // Line where the deviation was found
+	Column int   // Column (rune index) where the deviation was found
+	Err    error // The deviation found
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d, column %d: %s", w.Line, w.Column, w.Err)
+}
+
+// WithWarningHandler configures the Reader to call fn for each deviation from good practice it finds in the
+// input, such as malformed language tags, unnecessary escapes and IRIs that do not conform to RFC 3987.
+// These are not errors and reading continues normally. Checking for them has a small cost, so they are only
+// checked for when a handler is configured. IRIs are not checked if WithStrictIRIs is also used, which makes
+// them errors.
+func WithWarningHandler(fn func(Warning)) Option {
+	return func(r *Reader) {
+		r.onWarning = fn
+	}
+}
+
+// warn reports err to r.onWarning, annotated with the current column and line number.
+func (r *Reader) warn(err error) {
+	r.onWarning(Warning{Line: r.line, Column: r.column, Err: err})
+}
+
+// needsCodepointEscape reports whether r must be written as a \u or \U escape in a literal to produce
+// canonical output, which is the case for control characters that have no shorter escape.
+func needsCodepointEscape(r rune) bool {
+	switch r {
+	case '\b', '\t', '\n', '\f', '\r':
+		return false
+	}
+	return r < 0x20 || r == 0x7F
+}
+
+// isWellFormedLanguageTag reports whether tag has the form of a BCP 47 language tag, without checking that
+// its subtags are registered. The primary language subtag must be two, three or five to eight letters, or a
+// singleton introducing a private use or grandfathered tag, and every other subtag one to eight letters or
+// digits. A singleton subtag must be followed by another subtag.
+func isWellFormedLanguageTag(tag string) bool {
+	subtags := strings.Split(tag, "-")
+	primary := subtags[0]
+	switch {
+	case len(primary) == 1:
+		if primary != "x" && primary != "X" && primary != "i" && primary != "I" {
+			return false
+		}
+	case len(primary) == 4 || len(primary) > 8:
+		return false
+	}
+	for i, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 {
+			return false
+		}
+		if len(subtag) == 1 && i == len(subtags)-1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  []error
+	}{
+		{
+			name:  "clean",
+			input: `<http://example/s> <http://example/p> "tab\there \u0001 \"quoted\""@en-GB .`,
+			want:  nil,
+		},
+		{
+			name:  "long primary subtag",
+			input: `<http://example/s> <http://example/p> "x"@englishlanguage .`,
+			want:  []error{ErrMalformedLanguageTag},
+		},
+		{
+			name:  "long subtag",
+			input: `<http://example/s> <http://example/p> "x"@en-abcdefghi .`,
+			want:  []error{ErrMalformedLanguageTag},
+		},
+		{
+			name:  "trailing singleton",
+			input: `<http://example/s> <http://example/p> "x"@en-x--ltr .`,
+			want:  []error{ErrMalformedLanguageTag},
+		},
+		{
+			name:  "private use",
+			input: `<http://example/s> <http://example/p> "x"@x-private .`,
+			want:  nil,
+		},
+		{
+			name:  "literal escapes",
+			input: `<http://example/s> <http://example/p> "\u0041\u000A\u0022\U0001F600" .`,
+			want:  []error{ErrNonCanonicalEscape, ErrNonCanonicalEscape, ErrNonCanonicalEscape, ErrNonCanonicalEscape},
+		},
+		{
+			name:  "iri escapes",
+			input: `<http://example/s> <http://example/p> <http://example/\u0061> .`,
+			want:  []error{ErrNonCanonicalEscape},
+		},
+		{
+			name:  "escaped unwise iri",
+			input: `<http://example/s> <http://example/p> <http://example/\u007B> .`,
+			want:  []error{ErrUnwiseIRI},
+		},
+		{
+			name:  "unwise iri",
+			input: `<http://example/s> <http://example/p> <http://example/a\u0020b> .`,
+			want:  []error{ErrUnwiseIRI},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []Warning
+			r := NewReader(strings.NewReader(tc.input+"\n"), WithWarningHandler(func(w Warning) {
+				got = append(got, w)
+			}))
+			for r.Next() {
+			}
+			if r.Err() != nil {
+				t.Fatalf("got unexpected error %q", r.Err())
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got warnings %v, wanted %v", got, tc.want)
+			}
+			for i := range tc.want {
+				if !errors.Is(got[i].Err, tc.want[i]) {
+					t.Errorf("%d: got warning %v, wanted %v", i, got[i], tc.want[i])
+				}
+				if got[i].Line != 1 {
+					t.Errorf("%d: got warning on line %d, wanted 1", i, got[i].Line)
+				}
+			}
+		})
+	}
+}
+
+func TestWarningsStrictIRIs(t *testing.T) {
+	var got []Warning
+	r := NewReader(strings.NewReader("<http://example/s> <http://example/p> <http://example/a\\u0020b> .\n"), WithStrictIRIs(), WithWarningHandler(func(w Warning) {
+		got = append(got, w)
+	}))
+	for r.Next() {
+	}
+	if !errors.Is(r.Err(), ErrInvalidIRI) {
+		t.Errorf("got error %v, wanted %v", r.Err(), ErrInvalidIRI)
+	}
+	if len(got) != 0 {
+		t.Errorf("got unexpected warnings %v", got)
+	}
+}
@@ -0,0 +1,291 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/iand/gordf"
+)
+
+// A Writer writes quads using the N-Quads encoding.
+//
+// As returned by NewWriter, a Writer writes one statement per line, each terminated by a newline, with
+// a space preceding the final '.' of each statement. The exported fields can be changed to customize the
+// details before the first call to Write.
+//
+// By default characters outside the ASCII range are written as UTF-8. Setting EscapeASCII writes them using
+// \u or \U escapes instead, for loaders that only accept ASCII input. Blank node labels cannot be escaped in
+// N-Quads and are never escaped.
+//
+// Setting BlankNodes to a new BlankNodeMap replaces blank node labels with a stable sequence of labels, b0,
+// b1 and so on, in order of first occurrence. The output then does not reveal the labels used internally
+// and is the same for each run over the same input.
+//
+// Output is buffered and only written to the underlying io.Writer when the buffer is full or Flush is called.
+// Setting FlushQuads or FlushBytes additionally flushes the output once that many quads or bytes have been
+// written since the last flush, bounding the amount of output held back from a pipe or network connection.
+// NewWriterSize can be used to choose the size of the buffer.
+//
+// Language tags are compared case-insensitively but many stores treat differently cased tags as distinct.
+// Setting NormalizeLanguage writes tags in the conventional case recommended by BCP 47, such as en-GB or
+// zh-Hant-TW.
+//
+// Literals typed as xsd:string are equivalent to literals without a datatype. Setting OmitStringDatatype
+// writes them without the redundant datatype, which is also the canonical form.
+type Writer struct {
+	UseCRLF            bool          // true to use \r\n as the line terminator
+	OmitSpaceBeforeDot bool          // true to write the final '.' immediately after the last term
+	EscapeASCII        bool          // true to escape all non-ASCII characters in IRIs and literals
+	BlankNodes         *BlankNodeMap // if not nil, used to relabel blank nodes before they are written
+	FlushQuads         int           // if positive, flush after this many quads have been written
+	FlushBytes         int           // if positive, flush after this many bytes have been written
+	NormalizeLanguage  bool          // true to write language tags in their conventional case
+	OmitStringDatatype bool          // true to omit the datatype of literals typed as xsd:string
+
+	w            *bufio.Writer
+	buf          []byte
+	pendingQuads int // quads written since the last flush
+	pendingBytes int // bytes written since the last flush
+}
+
+// NewWriter returns a new Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: bufio.NewWriter(w),
+	}
+}
+
+// NewWriterSize returns a new Writer that writes to w using a buffer of at least size bytes.
+func NewWriterSize(w io.Writer, size int) *Writer {
+	return &Writer{
+		w: bufio.NewWriterSize(w, size),
+	}
+}
+
+// Write writes a single quad to w. Writes are buffered, so Flush must eventually be called to ensure
+// that the quad is written to the underlying io.Writer.
+func (w *Writer) Write(q Quad) error {
+	var err error
+	if w.buf, err = w.appendStatement(w.buf[:0], q); err != nil {
+		return err
+	}
+	return w.write(w.buf, 1)
+}
+
+// write writes b, which holds n statements, to the buffer and flushes it if the thresholds set by
+// FlushQuads or FlushBytes have been reached.
+func (w *Writer) write(b []byte, n int) error {
+	if _, err := w.w.Write(b); err != nil {
+		return err
+	}
+
+	w.pendingQuads += n
+	w.pendingBytes += len(b)
+	if (w.FlushQuads > 0 && w.pendingQuads >= w.FlushQuads) || (w.FlushBytes > 0 && w.pendingBytes >= w.FlushBytes) {
+		return w.flush()
+	}
+	return nil
+}
+
+// appendStatement appends the statement for q, including its line terminator, to dst as configured by the
+// exported fields of w.
+func (w *Writer) appendStatement(dst []byte, q Quad) ([]byte, error) {
+	if w.BlankNodes != nil {
+		var err error
+		if q, err = w.relabel(q); err != nil {
+			return dst, err
+		}
+	}
+	if w.NormalizeLanguage && q.O.Language != "" {
+		q.O.Language = normalizeLanguageTag(q.O.Language)
+	}
+	if w.OmitStringDatatype && q.O.Datatype == xsdString {
+		q.O.Datatype = ""
+	}
+	if w.EscapeASCII {
+		dst = appendTermsASCII(dst, q)
+	} else {
+		dst = appendTerms(dst, q)
+	}
+	if !w.OmitSpaceBeforeDot {
+		dst = append(dst, ' ')
+	}
+	dst = append(dst, '.')
+	if w.UseCRLF {
+		dst = append(dst, '\r')
+	}
+	return append(dst, '\n'), nil
+}
+
+// flush writes any buffered data to the underlying io.Writer and resets the automatic flush counters.
+func (w *Writer) flush() error {
+	w.pendingQuads = 0
+	w.pendingBytes = 0
+	return w.w.Flush()
+}
+
+// WriteAll writes multiple quads to w using Write and then calls Flush, returning any error from the Write
+// or Flush.
+func (w *Writer) WriteAll(quads []Quad) error {
+	for _, q := range quads {
+		if err := w.Write(q); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// WriteComment writes text as a comment. Each line of text is written as a separate comment line so that
+// line breaks in text cannot end the comment early. Like Write, the comment is buffered and counts towards
+// FlushBytes.
+func (w *Writer) WriteComment(text string) error {
+	w.buf = w.buf[:0]
+	for {
+		line, rest, more := strings.Cut(text, "\n")
+		line = strings.TrimSuffix(line, "\r")
+		w.buf = append(w.buf, '#')
+		if line != "" {
+			w.buf = append(w.buf, ' ')
+			w.buf = append(w.buf, strings.ReplaceAll(line, "\r", " ")...)
+		}
+		if w.UseCRLF {
+			w.buf = append(w.buf, '\r')
+		}
+		w.buf = append(w.buf, '\n')
+		if !more {
+			return w.write(w.buf, 0)
+		}
+		text = rest
+	}
+}
+
+// relabel returns q with its blank nodes, including those within quoted triples, relabeled using
+// w.BlankNodes.
+func (w *Writer) relabel(q Quad) (Quad, error) {
+	var err error
+	mapLabel := func(label string) string {
+		if err != nil {
+			return label
+		}
+		var mapped string
+		if mapped, err = w.BlankNodes.Map(label); err != nil {
+			return label
+		}
+		return mapped
+	}
+	for _, t := range [...]*rdf.Term{&q.S, &q.O, &q.G} {
+		if *t = relabelBlankNodes(*t, mapLabel); err != nil {
+			return q, err
+		}
+	}
+	return q, nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer. To check if an error occurred during the
+// Flush, call Error.
+func (w *Writer) Flush() {
+	w.flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *Writer) Error() error {
+	_, err := w.w.Write(nil)
+	return err
+}
+
+// appendTermsASCII is like appendTerms but escapes any non-ASCII characters in IRIs and literals.
+func appendTermsASCII(dst []byte, q Quad) []byte {
+	dst = appendTermASCII(dst, q.S)
+	dst = append(dst, ' ')
+	dst = appendTermASCII(dst, q.P)
+	dst = append(dst, ' ')
+	dst = appendTermASCII(dst, q.O)
+	if q.G.Kind != rdf.UnknownTerm {
+		dst = append(dst, ' ')
+		dst = appendTermASCII(dst, q.G)
+	}
+	return dst
+}
+
+// appendTermASCII appends the N-Quads serialization of t to dst, replacing any non-ASCII characters with
+// \u or \U escapes unless t is a blank node, whose label cannot be escaped. The terms of a quoted triple
+// are escaped in the same way.
+func appendTermASCII(dst []byte, t rdf.Term) []byte {
+	if t.Kind == QuotedTripleTerm {
+		r := newStatementReader([]byte(t.Value + " ."))
+		if !r.next() {
+			// not produced by the Reader or QuotedTriple, so its terms cannot be escaped
+			return AppendTerm(dst, t)
+		}
+		dst = append(dst, "<< "...)
+		dst = appendTermsASCII(dst, r.Quad())
+		return append(dst, " >>"...)
+	}
+
+	start := len(dst)
+	dst = AppendTerm(dst, t)
+	if t.Kind == rdf.BlankTerm {
+		return dst
+	}
+	for i := start; i < len(dst); i++ {
+		if dst[i] >= utf8.RuneSelf {
+			text := string(dst[i:])
+			dst = dst[:i]
+			for _, r1 := range text {
+				if r1 >= utf8.RuneSelf {
+					dst = appendCodepoint(dst, r1)
+					continue
+				}
+				dst = append(dst, byte(r1))
+			}
+			break
+		}
+	}
+	return dst
+}
+
+// normalizeLanguageTag returns tag with the case conventions of BCP 47 applied. The language subtag and
+// any extension or private use subtags are lowercase, two letter region subtags are uppercase and four
+// letter script subtags are titlecase.
+func normalizeLanguageTag(tag string) string {
+	b := []byte(tag)
+	start := 0
+	extension := false // true once a singleton subtag introducing an extension has been seen
+	for i := 0; i <= len(b); i++ {
+		if i < len(b) && b[i] != '-' {
+			continue
+		}
+		subtag := b[start:i]
+		for j, c := range subtag {
+			if 'A' <= c && c <= 'Z' {
+				subtag[j] = c + 'a' - 'A'
+			}
+		}
+		if len(subtag) == 1 {
+			extension = true
+		}
+		if start > 0 && !extension {
+			switch len(subtag) {
+			case 2:
+				for j, c := range subtag {
+					if 'a' <= c && c <= 'z' {
+						subtag[j] = c - 'a' + 'A'
+					}
+				}
+			case 4:
+				if c := subtag[0]; 'a' <= c && c <= 'z' {
+					subtag[0] = c - 'a' + 'A'
+				}
+			}
+		}
+		start = i + 1
+	}
+	return string(b)
+}
@@ -0,0 +1,389 @@
+/*
+  This is free and unencumbered software released into the public domain. For more
+  information, see <http://unlicense.org/> or the accompanying UNLICENSE file.
+*/
+
+package nquads
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iand/gordf"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	var want string
+
+	w := NewWriter(&buf)
+	for _, tc := range messageCases {
+		if err := w.Write(tc.quad); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+		want += tc.encoded + "\n"
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestWriterTerminators(t *testing.T) {
+	q := Quad{
+		S: rdf.IRI("http://example/s"),
+		P: rdf.IRI("http://example/p"),
+		O: rdf.Literal("o"),
+	}
+
+	testCases := []struct {
+		name      string
+		crlf      bool
+		omitSpace bool
+		want      string
+	}{
+		{name: "default", want: "<http://example/s> <http://example/p> \"o\" .\n"},
+		{name: "crlf", crlf: true, want: "<http://example/s> <http://example/p> \"o\" .\r\n"},
+		{name: "omit-space", omitSpace: true, want: "<http://example/s> <http://example/p> \"o\".\n"},
+		{name: "crlf-omit-space", crlf: true, omitSpace: true, want: "<http://example/s> <http://example/p> \"o\".\r\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.UseCRLF = tc.crlf
+			w.OmitSpaceBeforeDot = tc.omitSpace
+			if err := w.Write(q); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+
+			// Output must remain parseable
+			r := NewReader(&buf)
+			if !r.Next() {
+				t.Fatalf("failed to parse output: %v", r.Err())
+			}
+			if r.Quad() != q {
+				t.Errorf("got parsed quad %s, wanted %s", r.Quad(), q)
+			}
+		})
+	}
+}
+
+func TestWriterWriteAll(t *testing.T) {
+	var quads []Quad
+	var want string
+	for _, tc := range messageCases {
+		quads = append(quads, tc.quad)
+		want += tc.encoded + "\n"
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteAll(quads); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	// WriteAll flushes so no further call to Flush is needed
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	if err := NewWriter(failingWriter{}).WriteAll(quads); err == nil {
+		t.Errorf("got no error, wanted write failure")
+	}
+}
+
+func TestWriterEscapeASCII(t *testing.T) {
+	q := Quad{
+		S: rdf.IRI("http://example/café"),
+		P: rdf.IRI("http://example/p"),
+		O: rdf.LiteralWithLanguage("naïve 😀", "fr"),
+		G: rdf.Blank("é"),
+	}
+	quoted := Quad{
+		S: QuotedTriple(rdf.Blank("é"), rdf.IRI("http://example/café"), rdf.Literal("naïve")),
+		P: rdf.IRI("http://example/p"),
+		O: QuotedTriple(rdf.IRI("http://example/s"), rdf.IRI("http://example/p"), QuotedTriple(rdf.Blank("b"), rdf.IRI("http://example/p"), rdf.Literal("😀"))),
+	}
+
+	testCases := []struct {
+		name   string
+		quad   Quad
+		escape bool
+		want   string
+	}{
+		{name: "utf8", quad: q, want: "<http://example/café> <http://example/p> \"naïve 😀\"@fr _:é .\n"},
+		{name: "ascii", quad: q, escape: true, want: "<http://example/caf\\u00E9> <http://example/p> \"na\\u00EFve \\U0001F600\"@fr _:é .\n"},
+		{
+			name:   "quoted triple",
+			quad:   quoted,
+			escape: true,
+			want:   "<< _:é <http://example/caf\\u00E9> \"na\\u00EFve\" >> <http://example/p> << <http://example/s> <http://example/p> << _:b <http://example/p> \"\\U0001F600\" >> >> .\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.EscapeASCII = tc.escape
+			if err := w.Write(tc.quad); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			w.Flush()
+
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, wanted %q", got, tc.want)
+			}
+
+			r := NewReader(&buf)
+			if !r.Next() {
+				t.Fatalf("failed to parse output: %v", r.Err())
+			}
+			if r.Quad() != tc.quad {
+				t.Errorf("got parsed quad %s, wanted %s", r.Quad(), tc.quad)
+			}
+		})
+	}
+}
+
+func TestWriterBlankNodes(t *testing.T) {
+	input := `_:x <http://example/p> _:y .
+_:y <http://example/p> "y" _:g .
+<http://example/s> <http://example/p> _:x _:g .
+<< _:a <http://example/p> << _:y <http://example/p> _:b >> >> <http://example/p> _:x .
+`
+	want := `_:b0 <http://example/p> _:b1 .
+_:b1 <http://example/p> "y" _:b2 .
+<http://example/s> <http://example/p> _:b0 _:b2 .
+<< _:b3 <http://example/p> << _:b1 <http://example/p> _:b4 >> >> <http://example/p> _:b0 .
+`
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BlankNodes = &BlankNodeMap{}
+	r := NewReader(strings.NewReader(input))
+	for r.Next() {
+		if err := w.Write(r.Quad()); err != nil {
+			t.Fatalf("got unexpected error %q", err)
+		}
+	}
+	if r.Err() != nil {
+		t.Fatalf("got unexpected error %q", r.Err())
+	}
+	w.Flush()
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestWriterBlankNodesLimit(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BlankNodes = &BlankNodeMap{MaxLabels: 1}
+
+	q := Quad{S: rdf.Blank("x"), P: rdf.IRI("http://example/p"), O: rdf.Blank("y")}
+	if err := w.Write(q); err != ErrTooManyBlankNodes {
+		t.Errorf("got error %v, wanted %v", err, ErrTooManyBlankNodes)
+	}
+
+	q = Quad{S: QuotedTriple(rdf.Blank("x"), rdf.IRI("http://example/p"), rdf.Blank("z")), P: rdf.IRI("http://example/p"), O: rdf.Literal("o")}
+	if err := w.Write(q); err != ErrTooManyBlankNodes {
+		t.Errorf("got error %v for a quoted triple, wanted %v", err, ErrTooManyBlankNodes)
+	}
+}
+
+// countingFlushWriter records the size of each write made to it.
+type countingFlushWriter struct {
+	writes []int
+}
+
+func (c *countingFlushWriter) Write(p []byte) (int, error) {
+	c.writes = append(c.writes, len(p))
+	return len(p), nil
+}
+
+func TestWriterAutoFlush(t *testing.T) {
+	q := messageCases[0].quad
+	size := len(messageCases[0].encoded) + 1
+
+	testCases := []struct {
+		name       string
+		flushQuads int
+		flushBytes int
+		want       []int
+	}{
+		{name: "none", want: nil},
+		{name: "quads", flushQuads: 2, want: []int{2 * size, 2 * size}},
+		{name: "bytes", flushBytes: size + 1, want: []int{2 * size, 2 * size}},
+		{name: "both", flushQuads: 3, flushBytes: size, want: []int{size, size, size, size, size}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var c countingFlushWriter
+			w := NewWriterSize(&c, 4096)
+			w.FlushQuads = tc.flushQuads
+			w.FlushBytes = tc.flushBytes
+			for i := 0; i < 5; i++ {
+				if err := w.Write(q); err != nil {
+					t.Fatalf("got unexpected error %q", err)
+				}
+			}
+
+			if len(c.writes) != len(tc.want) {
+				t.Fatalf("got writes %v, wanted %v", c.writes, tc.want)
+			}
+			for i := range tc.want {
+				if c.writes[i] != tc.want[i] {
+					t.Errorf("got writes %v, wanted %v", c.writes, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguageTag(t *testing.T) {
+	testCases := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "en", want: "en"},
+		{tag: "EN", want: "en"},
+		{tag: "en-gb", want: "en-GB"},
+		{tag: "EN-gB", want: "en-GB"},
+		{tag: "zh-hant-tw", want: "zh-Hant-TW"},
+		{tag: "sr-LATN", want: "sr-Latn"},
+		{tag: "es-419", want: "es-419"},
+		{tag: "de-CH-1996", want: "de-CH-1996"},
+		{tag: "en-US-x-TWAIN", want: "en-US-x-twain"},
+		{tag: "X-Private-AB", want: "x-private-ab"},
+	}
+
+	for _, tc := range testCases {
+		if got := normalizeLanguageTag(tc.tag); got != tc.want {
+			t.Errorf("%s: got %s, wanted %s", tc.tag, got, tc.want)
+		}
+	}
+}
+
+func TestWriterNormalizeLanguage(t *testing.T) {
+	q := Quad{
+		S: rdf.IRI("http://example/s"),
+		P: rdf.IRI("http://example/p"),
+		O: rdf.LiteralWithLanguage("colour", "EN-gb"),
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.NormalizeLanguage = true
+	if err := w.Write(q); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	w.Flush()
+
+	want := "<http://example/s> <http://example/p> \"colour\"@en-GB .\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestWriterOmitStringDatatype(t *testing.T) {
+	quads := []Quad{
+		{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithDatatype("a", xsdString)},
+		{S: rdf.IRI("http://example/s"), P: rdf.IRI("http://example/p"), O: rdf.LiteralWithDatatype("1", xsdInteger)},
+	}
+	want := `<http://example/s> <http://example/p> "a" .
+<http://example/s> <http://example/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.OmitStringDatatype = true
+	if err := w.WriteAll(quads); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestWriterWriteComment(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		crlf bool
+		want string
+	}{
+		{name: "simple", text: "generated 2020-09-20", want: "# generated 2020-09-20\n"},
+		{name: "empty", text: "", want: "#\n"},
+		{name: "multiline", text: "line one\nline two\r\n\nline four", want: "# line one\n# line two\n#\n# line four\n"},
+		{name: "bare-cr", text: "a\rb", want: "# a b\n"},
+		{name: "crlf", text: "a\nb", crlf: true, want: "# a\r\n# b\r\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.UseCRLF = tc.crlf
+			if err := w.WriteComment(tc.text); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if err := w.Write(messageCases[0].quad); err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			w.Flush()
+
+			got := buf.String()
+			if !strings.HasPrefix(got, tc.want) {
+				t.Errorf("got %q, wanted prefix %q", got, tc.want)
+			}
+
+			// The comment must not disturb parsing of the following statement
+			r := NewReader(strings.NewReader(got))
+			if !r.Next() {
+				t.Fatalf("failed to parse output: %v", r.Err())
+			}
+			if r.Quad() != messageCases[0].quad {
+				t.Errorf("got parsed quad %s, wanted %s", r.Quad(), messageCases[0].quad)
+			}
+		})
+	}
+}
+
+func TestWriterWriteCommentFlushBytes(t *testing.T) {
+	var c countingFlushWriter
+	w := NewWriterSize(&c, 4096)
+	w.FlushBytes = 16
+
+	if err := w.WriteComment("a comment longer than the threshold"); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if err := w.WriteComment("short"); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+	if err := w.WriteComment("line one\nline two"); err != nil {
+		t.Fatalf("got unexpected error %q", err)
+	}
+
+	want := []int{len("# a comment longer than the threshold\n"), len("# short\n# line one\n# line two\n")}
+	if !slices.Equal(c.writes, want) {
+		t.Errorf("got writes %v, wanted %v", c.writes, want)
+	}
+}